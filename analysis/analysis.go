@@ -0,0 +1,97 @@
+// Package analysis provides go/analysis analyzers that catch common misuse
+// of nihil types:
+//
+//   - uncheckedvalue: reading n.String (or Int64, Time, ...) in a function
+//     that never checks n.Valid
+//   - timecompare: comparing NilTime values with == or !=
+//   - missingvalid: a NilString{String: "x"} literal that forgets Valid
+//   - notnulltag: a `gorm:"not null"` tag on a nihil field
+//
+// The nihil and nihilgorm packages themselves are not checked.
+//
+// Run them with go vet through the nihil-vet command:
+//
+//	go install github.com/mrrizkin/nihil/cmd/nihil-vet
+//	go vet -vettool=$(which nihil-vet) ./...
+//
+// or add Analyzers to a gopls or golangci-lint build.
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	nihilPath     = "github.com/mrrizkin/nihil"
+	nihilgormPath = "github.com/mrrizkin/nihil/nihilgorm"
+)
+
+// Analyzers lists every analyzer in the package
+var Analyzers = []*analysis.Analyzer{
+	UncheckedValue,
+	TimeCompare,
+	MissingValid,
+	NotNullTag,
+}
+
+// valueFields maps the nihil types to the name of their value field
+var valueFields = map[string]string{
+	"NilByte":    "Byte",
+	"NilBool":    "Bool",
+	"NilEnum":    "Enum",
+	"NilFloat64": "Float64",
+	"NilInt16":   "Int16",
+	"NilInt32":   "Int32",
+	"NilInt64":   "Int64",
+	"NilString":  "String",
+	"NilTime":    "Time",
+}
+
+// nihilName returns the name of the nihil type t is, points to, or wraps
+// like the nihilgorm types do
+func nihilName(t types.Type) (string, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	for {
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return "", false
+		}
+		switch named.Obj().Pkg().Path() {
+		case nihilPath:
+			name := named.Obj().Name()
+			_, ok := valueFields[name]
+			return name, ok
+		case nihilgormPath:
+			st, ok := named.Underlying().(*types.Struct)
+			if !ok || st.NumFields() == 0 || !st.Field(0).Embedded() {
+				return "", false
+			}
+			t = st.Field(0).Type()
+		default:
+			return "", false
+		}
+	}
+}
+
+// isNihilPackage reports whether the pass analyzes nihil itself, whose
+// code is allowed to handle the fields directly
+func isNihilPackage(pass *analysis.Pass) bool {
+	path := pass.Pkg.Path()
+	return path == nihilPath || path == nihilgormPath
+}
+
+// typeName returns the name of the type of e for diagnostics, e.g.
+// "nihil.NilString" or "nihilgorm.NilTime"
+func typeName(pass *analysis.Pass, e ast.Expr) string {
+	return types.TypeString(pass.TypesInfo.TypeOf(e), func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	})
+}
@@ -0,0 +1,24 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/mrrizkin/nihil/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestUncheckedValue(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analysis.UncheckedValue, "uncheckedvalue")
+}
+
+func TestTimeCompare(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analysis.TimeCompare, "timecompare")
+}
+
+func TestMissingValid(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analysis.MissingValid, "missingvalid")
+}
+
+func TestNotNullTag(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analysis.NotNullTag, "notnulltag")
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// MissingValid reports nihil literals that set the value but not Valid
+var MissingValid = &analysis.Analyzer{
+	Name: "missingvalid",
+	Doc: `report nihil literals that set the value field but not Valid
+
+NilString{String: "x"} is null: Valid defaults to false, so the value is
+dropped when the literal is marshaled or written to the database. Set
+Valid: true or use a constructor such as nihil.String("x").`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runMissingValid,
+}
+
+func runMissingValid(pass *analysis.Pass) (any, error) {
+	if isNihilPackage(pass) {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CompositeLit)(nil)}, func(n ast.Node) {
+		lit := n.(*ast.CompositeLit)
+		name, ok := nihilName(pass.TypesInfo.TypeOf(lit))
+		if !ok {
+			return
+		}
+
+		var value *ast.KeyValueExpr
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return // positional literals list every field
+			}
+			switch key, _ := kv.Key.(*ast.Ident); {
+			case key == nil:
+				return
+			case key.Name == "Valid":
+				return
+			case key.Name == valueFields[name]:
+				value = kv
+			}
+		}
+		if value == nil {
+			return
+		}
+
+		last := lit.Elts[len(lit.Elts)-1]
+		pass.Report(analysis.Diagnostic{
+			Pos: lit.Pos(),
+			End: lit.End(),
+			Message: typeName(pass, lit) + " literal sets " + valueFields[name] + " but not Valid, so it is null; " +
+				"set Valid: true or use nihil." + strings.TrimPrefix(name, "Nil"),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Set Valid: true",
+				TextEdits: []analysis.TextEdit{{Pos: last.End(), End: last.End(), NewText: []byte(", Valid: true")}},
+			}},
+		})
+	})
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// NotNullTag reports nihil fields tagged `gorm:"not null"`
+var NotNullTag = &analysis.Analyzer{
+	Name: "notnulltag",
+	Doc: `report nihil fields tagged gorm:"not null"
+
+A nihil field exists to hold null, but a NOT NULL column rejects it, so
+the insert fails at runtime for every null value. Use a non-nullable type
+for the field or drop "not null" from the tag.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runNotNullTag,
+}
+
+func runNotNullTag(pass *analysis.Pass) (any, error) {
+	if isNihilPackage(pass) {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil || len(field.Names) == 0 {
+				continue
+			}
+			if _, ok := nihilName(pass.TypesInfo.TypeOf(field.Type)); !ok {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil || !hasNotNull(reflect.StructTag(tag).Get("gorm")) {
+				continue
+			}
+			pass.ReportRangef(field.Tag, "%s is a nullable %s but tagged gorm:\"not null\", so null values fail to insert",
+				field.Names[0].Name, typeName(pass, field.Type))
+		}
+	})
+	return nil, nil
+}
+
+// hasNotNull reports whether a gorm tag has the "not null" setting
+func hasNotNull(tag string) bool {
+	for _, setting := range strings.Split(tag, ";") {
+		if strings.EqualFold(strings.TrimSpace(setting), "not null") {
+			return true
+		}
+	}
+	return false
+}
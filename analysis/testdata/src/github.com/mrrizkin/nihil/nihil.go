@@ -0,0 +1,23 @@
+// Package nihil is a stub of the nihil types for analyzer tests
+package nihil
+
+import (
+	"database/sql"
+	"time"
+)
+
+type (
+	NilBool            sql.NullBool
+	NilInt64           sql.NullInt64
+	NilString          sql.NullString
+	NilTime            sql.NullTime
+	NilEnum[E ~string] struct {
+		Enum  E
+		Valid bool
+	}
+)
+
+func String(s string) NilString        { return NilString{String: s, Valid: true} }
+func Time(t time.Time) NilTime         { return NilTime{Time: t, Valid: true} }
+func Int64(i int64) NilInt64           { return NilInt64{Int64: i, Valid: true} }
+func (n NilTime) Equal(u NilTime) bool { return n.Valid == u.Valid && n.Time.Equal(u.Time) }
@@ -0,0 +1,10 @@
+// Package nihilgorm is a stub of the nihilgorm wrapper types for analyzer tests
+package nihilgorm
+
+import "github.com/mrrizkin/nihil"
+
+type (
+	NilString struct{ nihil.NilString }
+	NilTime   struct{ nihil.NilTime }
+	DeletedAt struct{ nihil.NilTime }
+)
@@ -0,0 +1,17 @@
+package missingvalid
+
+import "github.com/mrrizkin/nihil"
+
+type Status string
+
+var (
+	name     = nihil.NilString{String: "gopher"}     // want `nihil.NilString literal sets String but not Valid, so it is null; set Valid: true or use nihil.String`
+	status   = nihil.NilEnum[Status]{Enum: "active"} // want `nihil.NilEnum\[Status\] literal sets Enum but not Valid`
+	balances = []nihil.NilInt64{
+		{Int64: 1}, // want `nihil.NilInt64 literal sets Int64 but not Valid`
+		{Int64: 2, Valid: true},
+		{3, true},
+	}
+	null  = nihil.NilString{}
+	valid = nihil.NilString{String: "gopher", Valid: true}
+)
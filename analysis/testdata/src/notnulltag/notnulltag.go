@@ -0,0 +1,14 @@
+package notnulltag
+
+import (
+	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/nihilgorm"
+)
+
+type User struct {
+	ID       int64               `gorm:"primaryKey;not null"`
+	Email    nihil.NilString     `gorm:"size:255;not null" json:"email"` // want `Email is a nullable nihil.NilString but tagged gorm:"not null"`
+	Nickname nihilgorm.NilString `gorm:"NOT NULL"`                       // want `Nickname is a nullable nihilgorm.NilString but tagged gorm:"not null"`
+	Bio      nihil.NilString     `gorm:"type:text"`
+	Name     string              `gorm:"not null"`
+}
@@ -0,0 +1,22 @@
+package timecompare
+
+import (
+	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/nihilgorm"
+)
+
+func compare(a, b nihil.NilTime, p *nihil.NilTime, w nihilgorm.NilTime, d nihilgorm.DeletedAt) bool {
+	if a == b { // want `nihil.NilTime compared with ==; use Equal`
+		return true
+	}
+	if *p != a { // want `nihil.NilTime compared with !=; use Equal`
+		return true
+	}
+	if w == w { // want `nihilgorm.NilTime compared with ==; use Equal`
+		return true
+	}
+	if d.NilTime == a { // want `nihil.NilTime compared with ==`
+		return true
+	}
+	return p == nil || a.Equal(b)
+}
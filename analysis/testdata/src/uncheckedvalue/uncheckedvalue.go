@@ -0,0 +1,52 @@
+package uncheckedvalue
+
+import (
+	"strings"
+
+	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/nihilgorm"
+)
+
+type User struct {
+	Email    nihil.NilString
+	Nickname nihilgorm.NilString
+	Balance  *nihil.NilInt64
+}
+
+func unchecked(u User) string {
+	return strings.ToLower(u.Email.String) // want `u.Email.String is read without checking u.Email.Valid`
+}
+
+func reportedOnce(u User) string {
+	return u.Email.String + u.Email.String // want `u.Email.String is read without checking`
+}
+
+func checked(u User) string {
+	if !u.Email.Valid {
+		return ""
+	}
+	return u.Email.String
+}
+
+func checkedInClosure(u User) func() string {
+	return func() string {
+		if u.Nickname.Valid {
+			return u.Nickname.String
+		}
+		return "anonymous"
+	}
+}
+
+func wrapper(u User) string {
+	return u.Nickname.String // want `u.Nickname.String is read without checking u.Nickname.Valid`
+}
+
+func pointer(u User) int64 {
+	return u.Balance.Int64 // want `u.Balance.Int64 is read without checking u.Balance.Valid`
+}
+
+func writes(u *User, s string) *string {
+	u.Email.String = s
+	u.Email.Valid = true
+	return &u.Nickname.String
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// TimeCompare reports NilTime values compared with == or !=
+var TimeCompare = &analysis.Analyzer{
+	Name: "timecompare",
+	Doc: `report NilTime values compared with == or !=
+
+== compares the zone and monotonic clock reading of the underlying
+time.Time, so equal instants read back from a database or converted to
+another zone compare unequal. Use NilTime.Equal instead.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runTimeCompare,
+}
+
+func runTimeCompare(pass *analysis.Pass) (any, error) {
+	if isNihilPackage(pass) {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.BinaryExpr)(nil)}, func(n ast.Node) {
+		expr := n.(*ast.BinaryExpr)
+		if expr.Op != token.EQL && expr.Op != token.NEQ {
+			return
+		}
+		t := pass.TypesInfo.TypeOf(expr.X)
+		if _, isPointer := t.(*types.Pointer); isPointer {
+			return
+		}
+		if name, ok := nihilName(t); !ok || name != "NilTime" {
+			return
+		}
+
+		// Equal takes a nihil.NilTime, which the wrapper types embed
+		arg := types.ExprString(expr.Y)
+		if !isNamed(pass.TypesInfo.TypeOf(expr.Y), nihilPath, "NilTime") {
+			arg += ".NilTime"
+		}
+		receiver := types.ExprString(expr.X)
+		switch expr.X.(type) {
+		case *ast.StarExpr, *ast.UnaryExpr:
+			receiver = "(" + receiver + ")"
+		}
+		fix := receiver + ".Equal(" + arg + ")"
+		if expr.Op == token.NEQ {
+			fix = "!" + fix
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     expr.Pos(),
+			End:     expr.End(),
+			Message: typeName(pass, expr.X) + " compared with " + expr.Op.String() + "; use Equal, which compares instants",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Use Equal",
+				TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(fix)}},
+			}},
+		})
+	})
+	return nil, nil
+}
+
+func isNamed(t types.Type, path, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// UncheckedValue reports reads of the value field of a nihil type in a
+// function that never looks at its Valid field
+var UncheckedValue = &analysis.Analyzer{
+	Name: "uncheckedvalue",
+	Doc: `report nihil values read without checking Valid
+
+The value field of a null NilString, NilInt64, ... holds the zero value,
+so reading n.String without checking n.Valid treats null as "". A read is
+reported when the function containing it never reads Valid on the same
+expression. Assignments and taking the field's address are not reads.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUncheckedValue,
+}
+
+func runUncheckedValue(pass *analysis.Pass) (any, error) {
+	if isNihilPackage(pass) {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		if body := n.(*ast.FuncDecl).Body; body != nil {
+			checkValueReads(pass, body)
+		}
+	})
+	return nil, nil
+}
+
+// checkValueReads reports the unchecked value reads in a function body
+func checkValueReads(pass *analysis.Pass, body *ast.BlockStmt) {
+	checked := map[string]bool{}  // expressions whose Valid is read
+	writes := map[ast.Expr]bool{} // selectors assigned to or addressed
+	var reads []*ast.SelectorExpr // value field selectors
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				writes[ast.Unparen(lhs)] = true
+			}
+		case *ast.IncDecStmt:
+			writes[ast.Unparen(n.X)] = true
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				writes[ast.Unparen(n.X)] = true
+			}
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[n]
+			if !ok || sel.Kind() != types.FieldVal {
+				return true
+			}
+			name, ok := nihilName(pass.TypesInfo.TypeOf(n.X))
+			if !ok {
+				return true
+			}
+			switch n.Sel.Name {
+			case "Valid":
+				checked[types.ExprString(n.X)] = true
+			case valueFields[name]:
+				reads = append(reads, n)
+			}
+		}
+		return true
+	})
+
+	reported := map[string]bool{}
+	for _, read := range reads {
+		x := types.ExprString(read.X)
+		if writes[read] || checked[x] || reported[x] {
+			continue
+		}
+		reported[x] = true
+		pass.ReportRangef(read, "%s.%s is read without checking %s.Valid, so null reads as the zero value",
+			x, read.Sel.Name, x)
+	}
+}
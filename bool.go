@@ -23,7 +23,7 @@ func (n *NilBool) isValid() bool        { return n.Valid }
 func (n *NilBool) getValue() bool       { return n.Bool }
 func (n *NilBool) setValid(valid bool)  { n.Valid = valid }
 func (n *NilBool) setValue(value bool)  { n.Bool = value }
-func (n *NilBool) scan(value any) error { return (*sql.NullBool)(n).Scan(value) }
+func (n *NilBool) scan(value any) error { return scanNullable(n, value, convertBool) }
 func (n *NilBool) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
@@ -2,6 +2,7 @@ package nihil
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -83,3 +84,53 @@ func TestNilBool_JSONUnmarshaling(t *testing.T) {
 		})
 	}
 }
+
+func TestNilBool_Scan(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         any
+		expectedValid bool
+		expectedValue bool
+	}{
+		{"nil", nil, false, false},
+		{"bool", true, true, true},
+		{"int64 one", int64(1), true, true},
+		{"int64 zero", int64(0), true, false},
+		{"mysql Y", []byte("Y"), true, true},
+		{"mysql N", []byte("N"), true, false},
+		{"postgres t", "t", true, true},
+		{"postgres f", "f", true, false},
+		{"yes", "yes", true, true},
+		{"no", "NO", true, false},
+		{"text one", "1", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Bool(true)
+			if err := result.Scan(tt.input); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Expected valid=%v, got valid=%v", tt.expectedValid, result.Valid)
+			}
+			if result.Bool != tt.expectedValue {
+				t.Errorf("Expected value=%v, got value=%v", tt.expectedValue, result.Bool)
+			}
+		})
+	}
+}
+
+func TestNilBool_ScanInvalid(t *testing.T) {
+	for _, input := range []any{"maybe", int64(2), 1.5} {
+		var result NilBool
+		err := result.Scan(input)
+		if err == nil {
+			t.Errorf("Expected error scanning %v", input)
+			continue
+		}
+		if !strings.Contains(err.Error(), "NilBool") {
+			t.Errorf("Expected error to name the target type, got %v", err)
+		}
+	}
+}
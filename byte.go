@@ -23,7 +23,7 @@ func (n *NilByte) isValid() bool        { return n.Valid }
 func (n *NilByte) getValue() byte       { return n.Byte }
 func (n *NilByte) setValid(valid bool)  { n.Valid = valid }
 func (n *NilByte) setValue(value byte)  { n.Byte = value }
-func (n *NilByte) scan(value any) error { return (*sql.NullByte)(n).Scan(value) }
+func (n *NilByte) scan(value any) error { return scanNullable(n, value, convertByte) }
 func (n *NilByte) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
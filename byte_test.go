@@ -96,3 +96,43 @@ func TestNilByte_DriverValue(t *testing.T) {
 		t.Errorf("Expected nil, got %v", val)
 	}
 }
+
+func TestNilByte_Scan(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         any
+		expectedValid bool
+		expectedValue byte
+		expectError   bool
+	}{
+		{"nil", nil, false, 0, false},
+		{"int64", int64(200), true, 200, false},
+		{"bytes", []byte("42"), true, 42, false},
+		{"string", "7", true, 7, false},
+		{"negative", int64(-1), false, 0, true},
+		{"overflow", "256", false, 0, true},
+		{"garbage", "abc", false, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result NilByte
+			err := result.Scan(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Expected valid=%v, got valid=%v", tt.expectedValid, result.Valid)
+			}
+			if result.Byte != tt.expectedValue {
+				t.Errorf("Expected value=%d, got value=%d", tt.expectedValue, result.Byte)
+			}
+		})
+	}
+}
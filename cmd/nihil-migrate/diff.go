@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change
+const contextLines = 3
+
+// unifiedDiff returns a unified diff of two versions of the file at path
+func unifiedDiff(path string, old, new []byte) string {
+	a := splitLines(old)
+	b := splitLines(new)
+	ops := diffLines(a, b)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// A hunk runs from the first change to the last change followed by
+		// more than two contexts' worth of unchanged lines
+		start := max(i-contextLines, 0)
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*contextLines {
+				break
+			}
+		}
+		end = min(end+contextLines, len(ops))
+
+		aStart, bStart := ops[start].a, ops[start].b
+		var aLen, bLen int
+		var body strings.Builder
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+			body.WriteByte(op.kind)
+			body.WriteString(op.line)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		out.WriteString(body.String())
+		i = end
+	}
+	return out.String()
+}
+
+// hunkRange formats the 0-based start and length of a hunk side
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// diffOp is one line of a diff: ' ' kept, '-' removed or '+' added. a and b
+// are the indexes of the line in the old and new versions.
+type diffOp struct {
+	kind byte
+	line string
+	a, b int
+}
+
+// diffLines returns the shortest edit script turning a into b, with
+// Myers' algorithm, which takes time and memory proportional to the number
+// of changed lines rather than to the product of the file lengths
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1) // v[offset+k] is the furthest x on diagonal k
+	var trace [][]int            // trace[d] is v[offset-d : offset+d+1] after d edits
+
+	for d := 0; ; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // insertion from diagonal k+1
+			} else {
+				x = v[offset+k-1] + 1 // deletion from diagonal k-1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+	}
+}
+
+// backtrack walks the furthest-reaching paths of diffLines back from the
+// end of both versions, which the len(trace)-th edit reached, returning the
+// edit script in order
+func backtrack(a, b []string, trace [][]int) []diffOp {
+	furthest := func(d, k int) int { return trace[d][k+d] }
+
+	var ops []diffOp
+	x, y := len(a), len(b)
+	for d := len(trace); d >= 0; d-- {
+		k := x - y
+		startX, startY := 0, 0 // where the diagonal run ending at x, y starts
+		insertion := false
+		if d > 0 {
+			insertion = k == -d || (k != d && furthest(d-1, k-1) < furthest(d-1, k+1))
+			if insertion {
+				startX = furthest(d-1, k+1)
+				startY = startX - k
+			} else {
+				startX = furthest(d-1, k-1) + 1
+				startY = startX - k
+			}
+		}
+		for x > startX && y > startY {
+			x--
+			y--
+			ops = append(ops, diffOp{' ', a[x], x, y})
+		}
+		if d == 0 {
+			break
+		}
+		if insertion {
+			y--
+			ops = append(ops, diffOp{'+', b[y], x, y})
+		} else {
+			x--
+			ops = append(ops, diffOp{'-', a[x], x, y})
+		}
+	}
+	slices.Reverse(ops)
+	return ops
+}
+
+// splitLines splits src after each newline, keeping the newlines
+func splitLines(src []byte) []string {
+	var lines []string
+	for len(src) > 0 {
+		i := bytes.IndexByte(src, '\n')
+		if i < 0 {
+			lines = append(lines, string(src)+"\n\\ No newline at end of file\n")
+			break
+		}
+		lines = append(lines, string(src[:i+1]))
+		src = src[i+1:]
+	}
+	return lines
+}
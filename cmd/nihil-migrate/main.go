@@ -0,0 +1,88 @@
+// Command nihil-migrate rewrites Go code from sql.Null* types and pointer
+// struct fields to nihil types.
+//
+// Usage:
+//
+//	nihil-migrate [-w] [packages]
+//
+// In the given packages (default "."):
+//
+//	sql.NullString                        nihil.NilString, everywhere it is used as a type
+//	sql.NullString{String: s, Valid: true}  nihil.String(s)
+//	sql.NullString{}                      nihil.StringNil()
+//	Name *string (struct fields)          Name nihil.NilString
+//
+// and likewise for the other sql.Null* types, *bool, *byte, *float64,
+// *int16, *int32, *int64 and *time.Time. Uses of converted pointer fields
+// are rewritten where the meaning is clear:
+//
+//	u.Name == nil   !u.Name.Valid
+//	u.Name != nil   u.Name.Valid
+//	*u.Name         u.Name.String
+//	u.Name = &s     u.Name = nihil.String(s)
+//	*u.Name = s     u.Name = nihil.String(s)
+//	u.Name = nil    u.Name = nihil.StringNil()
+//
+// Other uses, such as passing the field as a pointer, are reported on
+// standard error to be fixed by hand, as are converted fields tagged
+// omitempty, which a null nihil value no longer leaves out of the JSON.
+// Accesses to Valid and the value field of sql.Null* types need no change,
+// since the nihil types share their layout. Comments and formatting are
+// kept, and the nihil import is added while unused database/sql and time
+// imports are removed.
+//
+// The changes are printed as a unified diff; -w writes them to the files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write the changes to the files instead of printing a diff")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: nihil-migrate [flags] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(os.Stdout, os.Stderr, *write, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(stdout, stderr io.Writer, write bool, patterns []string) error {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	changes, warnings, err := migrate("", patterns...)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(stderr, w)
+	}
+
+	wd, _ := os.Getwd()
+	for _, c := range changes {
+		if write {
+			if err := os.WriteFile(c.Path, c.New, 0o644); err != nil {
+				return err
+			}
+			continue
+		}
+		path := c.Path
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			path = filepath.ToSlash(rel)
+		}
+		if _, err := io.WriteString(stdout, unifiedDiff(path, c.Old, c.New)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+const nihilPath = "github.com/mrrizkin/nihil"
+
+// nihilType describes the nihil type replacing a nullable Go type
+type nihilType struct {
+	Name  string // e.g. "NilString"
+	Ctor  string // e.g. "String"
+	Field string // value field, e.g. "String"
+}
+
+// sqlTypes maps the database/sql null types to nihil types
+var sqlTypes = map[string]nihilType{
+	"NullBool":    {"NilBool", "Bool", "Bool"},
+	"NullByte":    {"NilByte", "Byte", "Byte"},
+	"NullFloat64": {"NilFloat64", "Float64", "Float64"},
+	"NullInt16":   {"NilInt16", "Int16", "Int16"},
+	"NullInt32":   {"NilInt32", "Int32", "Int32"},
+	"NullInt64":   {"NilInt64", "Int64", "Int64"},
+	"NullString":  {"NilString", "String", "String"},
+	"NullTime":    {"NilTime", "Time", "Time"},
+}
+
+// pointerTypes maps the element types of pointer fields to nihil types
+var pointerTypes = map[types.BasicKind]nihilType{
+	types.Bool:    sqlTypes["NullBool"],
+	types.Uint8:   sqlTypes["NullByte"],
+	types.Float64: sqlTypes["NullFloat64"],
+	types.Int16:   sqlTypes["NullInt16"],
+	types.Int32:   sqlTypes["NullInt32"],
+	types.Int64:   sqlTypes["NullInt64"],
+	types.String:  sqlTypes["NullString"],
+}
+
+// fileChange is the rewritten source of one file
+type fileChange struct {
+	Path     string
+	Old, New []byte
+}
+
+// edit replaces the source bytes in [start, end) with text
+type edit struct {
+	start, end int
+	text       string
+}
+
+// migrate loads the packages matching patterns and rewrites their sql.Null*
+// types and pointer struct fields to nihil types. It returns the changed
+// files and warnings for uses it could not rewrite.
+func migrate(dir string, patterns ...string) ([]fileChange, []string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, nil, fmt.Errorf("nihil-migrate: failed to load %s", strings.Join(patterns, " "))
+	}
+
+	var changes []fileChange
+	var warnings []string
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == nihilPath {
+			continue
+		}
+		r := &rewriter{pkg: pkg, fields: map[*types.Var]nihilType{}, handled: map[*ast.Ident]bool{}}
+		for _, f := range pkg.Syntax {
+			r.collectFields(f)
+		}
+		for _, f := range pkg.Syntax {
+			change, err := r.rewrite(f)
+			if err != nil {
+				return nil, nil, err
+			}
+			if change != nil {
+				changes = append(changes, *change)
+			}
+		}
+		warnings = append(warnings, r.warnings...)
+	}
+	return changes, warnings, nil
+}
+
+// rewriter rewrites the files of one package
+type rewriter struct {
+	pkg      *packages.Package
+	fields   map[*types.Var]nihilType // pointer fields becoming nihil types
+	handled  map[*ast.Ident]bool      // uses of those fields already rewritten
+	edits    []edit
+	file     *token.File
+	qual     string // name the nihil package is imported as
+	warnings []string
+}
+
+// collectFields records the pointer struct fields of f that have a nihil type
+func (r *rewriter) collectFields(f *ast.File) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range st.Fields.List {
+			if len(field.Names) == 0 {
+				continue
+			}
+			nt, ok := r.pointerType(r.pkg.TypesInfo.TypeOf(field.Type))
+			if !ok {
+				continue
+			}
+			for _, name := range field.Names {
+				if v, ok := r.pkg.TypesInfo.Defs[name].(*types.Var); ok {
+					r.fields[v] = nt
+				}
+			}
+		}
+		return true
+	})
+}
+
+// pointerType returns the nihil type replacing a pointer type
+func (r *rewriter) pointerType(t types.Type) (nihilType, bool) {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return nihilType{}, false
+	}
+	if isNamed(p.Elem(), "time", "Time") {
+		return sqlTypes["NullTime"], true
+	}
+	if b, ok := p.Elem().(*types.Basic); ok {
+		nt, ok := pointerTypes[b.Kind()]
+		return nt, ok
+	}
+	return nihilType{}, false
+}
+
+// sqlType returns the nihil type replacing a database/sql null type
+func sqlType(t types.Type) (nihilType, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "database/sql" {
+		return nihilType{}, false
+	}
+	nt, ok := sqlTypes[named.Obj().Name()]
+	return nt, ok
+}
+
+func isNamed(t types.Type, path, name string) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// rewrite returns the rewritten source of f, or nil when nothing changes
+func (r *rewriter) rewrite(f *ast.File) (*fileChange, error) {
+	r.file = r.pkg.Fset.File(f.Pos())
+	r.edits = nil
+	r.qual = "nihil"
+	for _, imp := range f.Imports {
+		if strings.Trim(imp.Path.Value, `"`) == nihilPath && imp.Name != nil {
+			r.qual = imp.Name.Name
+		}
+	}
+
+	ast.Inspect(f, r.visit)
+	r.warnUnhandled(f)
+	if len(r.edits) == 0 {
+		return nil, nil
+	}
+
+	path := r.file.Name()
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.apply(path, src)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(src, out) {
+		return nil, nil
+	}
+	return &fileChange{Path: path, Old: src, New: out}, nil
+}
+
+// visit records the edits for one node
+func (r *rewriter) visit(n ast.Node) bool {
+	info := r.pkg.TypesInfo
+	switch n := n.(type) {
+	case *ast.Field:
+		if len(n.Names) > 0 {
+			if v, ok := info.Defs[n.Names[0]].(*types.Var); ok && v.IsField() {
+				if nt, ok := r.fields[v]; ok {
+					r.replace(n.Type, r.qual+"."+nt.Name)
+					r.warnOmitEmpty(n, nt)
+					return false
+				}
+			}
+		}
+
+	case *ast.SelectorExpr:
+		// sql.NullString as a type, in declarations, conversions and literals
+		if obj, ok := info.Uses[n.Sel].(*types.TypeName); ok {
+			if nt, ok := sqlType(obj.Type()); ok {
+				r.replace(n, r.qual+"."+nt.Name)
+				return false
+			}
+		}
+
+	case *ast.CompositeLit:
+		if nt, ok := sqlType(info.TypeOf(n)); ok && n.Type != nil {
+			return r.sqlLiteral(n, nt)
+		}
+		r.keyedFields(n)
+
+	case *ast.BinaryExpr:
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return true
+		}
+		sel, other, selFirst := n.X, n.Y, true
+		if isNil(sel) {
+			sel, other, selFirst = n.Y, n.X, false
+		}
+		if _, ok := r.field(sel); !ok || !isNil(other) {
+			return true
+		}
+		not := ""
+		if n.Op == token.EQL {
+			not = "!"
+		}
+		if selFirst {
+			r.insert(sel.Pos(), not)
+			r.replaceRange(sel.End(), n.End(), ".Valid")
+		} else {
+			r.replaceRange(n.Pos(), sel.Pos(), not)
+			r.insert(sel.End(), ".Valid")
+		}
+		r.markHandled(sel)
+
+	case *ast.AssignStmt:
+		if n.Tok != token.ASSIGN || len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+			return true
+		}
+		lhs := n.Lhs[0]
+		if star, ok := lhs.(*ast.StarExpr); ok {
+			if nt, ok := r.field(star.X); ok {
+				// *u.Name = v sets both the value and Valid
+				r.replaceRange(star.Pos(), star.X.Pos(), "")
+				r.wrap(n.Rhs[0], r.qual+"."+nt.Ctor)
+				r.markHandled(star.X)
+				return true
+			}
+		}
+		if nt, ok := r.field(lhs); ok {
+			if r.pointerValue(n.Rhs[0], nt) {
+				r.markHandled(lhs)
+			}
+		}
+
+	case *ast.StarExpr:
+		if nt, ok := r.field(n.X); ok && !r.isHandled(n.X) {
+			r.replaceRange(n.Pos(), n.X.Pos(), "")
+			r.insert(n.X.End(), "."+nt.Field)
+			r.markHandled(n.X)
+		}
+	}
+	return true
+}
+
+// sqlLiteral rewrites sql.NullString{String: v, Valid: true} to
+// nihil.String(v), and literals without Valid to nihil.StringNil()
+func (r *rewriter) sqlLiteral(lit *ast.CompositeLit, nt nihilType) bool {
+	var value ast.Expr
+	valid := "false"
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return true // positional; only the type is rewritten
+		}
+		switch key := kv.Key.(*ast.Ident); {
+		case key == nil:
+			return true
+		case key.Name == "Valid":
+			id, ok := kv.Value.(*ast.Ident)
+			if !ok || (id.Name != "true" && id.Name != "false") {
+				return true
+			}
+			valid = id.Name
+		default:
+			value = kv.Value
+		}
+	}
+
+	switch {
+	case valid == "true" && value != nil:
+		r.replaceRange(lit.Pos(), value.Pos(), r.qual+"."+nt.Ctor+"(")
+		r.replaceRange(value.End(), lit.End(), ")")
+		ast.Inspect(value, r.visit) // value may hold more rewrites
+		return false
+	case valid == "false":
+		r.replace(lit, r.qual+"."+nt.Ctor+"Nil()")
+		return false
+	}
+	return true
+}
+
+// keyedFields rewrites the values of converted pointer fields in a keyed
+// struct literal
+func (r *rewriter) keyedFields(lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		v, ok := r.pkg.TypesInfo.Uses[key].(*types.Var)
+		if !ok {
+			continue
+		}
+		if nt, ok := r.fields[v]; ok && r.pointerValue(kv.Value, nt) {
+			r.handled[key] = true
+		}
+	}
+}
+
+// pointerValue rewrites a value stored in a converted pointer field:
+// nil becomes nihil.StringNil() and &v becomes nihil.String(v)
+func (r *rewriter) pointerValue(value ast.Expr, nt nihilType) bool {
+	if isNil(value) {
+		r.replace(value, r.qual+"."+nt.Ctor+"Nil()")
+		return true
+	}
+	if u, ok := ast.Unparen(value).(*ast.UnaryExpr); ok && u.Op == token.AND {
+		if _, isLit := u.X.(*ast.CompositeLit); !isLit {
+			r.replaceRange(value.Pos(), u.X.Pos(), r.qual+"."+nt.Ctor+"(")
+			r.replaceRange(u.X.End(), value.End(), ")")
+			return true
+		}
+	}
+	return false
+}
+
+// field returns the nihil type of a selector naming a converted pointer field
+func (r *rewriter) field(e ast.Expr) (nihilType, bool) {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return nihilType{}, false
+	}
+	v, ok := r.pkg.TypesInfo.Uses[sel.Sel].(*types.Var)
+	if !ok {
+		return nihilType{}, false
+	}
+	nt, ok := r.fields[v]
+	return nt, ok
+}
+
+func (r *rewriter) markHandled(e ast.Expr) {
+	r.handled[ast.Unparen(e).(*ast.SelectorExpr).Sel] = true
+}
+
+func (r *rewriter) isHandled(e ast.Expr) bool {
+	return r.handled[ast.Unparen(e).(*ast.SelectorExpr).Sel]
+}
+
+// warnUnhandled reports the uses of converted pointer fields left as is
+func (r *rewriter) warnUnhandled(f *ast.File) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || r.handled[id] {
+			return true
+		}
+		if v, ok := r.pkg.TypesInfo.Uses[id].(*types.Var); ok {
+			if nt, ok := r.fields[v]; ok {
+				r.warnings = append(r.warnings, fmt.Sprintf("%s: %s is now %s.%s; update this use by hand",
+					r.pkg.Fset.Position(id.Pos()), id.Name, r.qual, nt.Name))
+			}
+		}
+		return true
+	})
+}
+
+// warnOmitEmpty reports a converted pointer field tagged omitempty: a nil
+// pointer was left out of the JSON, but a null nihil value is written as
+// null, since omitempty never omits a struct
+func (r *rewriter) warnOmitEmpty(field *ast.Field, nt nihilType) {
+	if field.Tag == nil {
+		return
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	_, options, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	if !slices.Contains(strings.Split(options, ","), "omitempty") {
+		return
+	}
+	for _, name := range field.Names {
+		r.warnings = append(r.warnings, fmt.Sprintf("%s: %s is now %s.%s, which omitempty does not omit; "+
+			"null is written where the field was left out, use omitzero to keep leaving it out",
+			r.pkg.Fset.Position(name.Pos()), name.Name, r.qual, nt.Name))
+	}
+}
+
+func isNil(e ast.Expr) bool {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	return ok && id.Name == "nil"
+}
+
+func (r *rewriter) offset(pos token.Pos) int { return r.file.Offset(pos) }
+
+func (r *rewriter) replace(n ast.Node, text string) { r.replaceRange(n.Pos(), n.End(), text) }
+
+func (r *rewriter) insert(pos token.Pos, text string) { r.replaceRange(pos, pos, text) }
+
+func (r *rewriter) wrap(e ast.Expr, fn string) {
+	r.insert(e.Pos(), fn+"(")
+	r.insert(e.End(), ")")
+}
+
+func (r *rewriter) replaceRange(start, end token.Pos, text string) {
+	r.edits = append(r.edits, edit{r.offset(start), r.offset(end), text})
+}
+
+// apply applies the edits to src, fixes the imports and formats the result
+func (r *rewriter) apply(path string, src []byte) ([]byte, error) {
+	edits := slices.Clone(r.edits)
+	slices.SortStableFunc(edits, func(a, b edit) int { return a.start - b.start })
+
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		if e.start < last {
+			return nil, fmt.Errorf("nihil-migrate: %s: overlapping rewrites at offset %d", path, e.start)
+		}
+		buf.Write(src[last:e.start])
+		buf.WriteString(e.text)
+		last = e.end
+	}
+	buf.Write(src[last:])
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("nihil-migrate: %s: rewrite produced invalid code: %w", path, err)
+	}
+	if r.qual == "nihil" {
+		astutil.AddImport(fset, f, nihilPath)
+	}
+	for _, path := range []string{"database/sql", "time"} {
+		if !astutil.UsesImport(f, path) {
+			astutil.DeleteImport(fset, f, path)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, f); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
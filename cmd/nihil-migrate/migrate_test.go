@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	changes, warnings, err := migrate("testdata/models", ".")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 changed file, got %d", len(changes))
+	}
+	if filepath.Base(changes[0].Path) != "models.go" {
+		t.Errorf("Expected models.go to change, got %s", changes[0].Path)
+	}
+
+	expected, err := os.ReadFile("testdata/models.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(changes[0].New, expected) {
+		t.Errorf("Unexpected rewrite:\n%s", unifiedDiff("models.golden", expected, changes[0].New))
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %q", warnings)
+	}
+	if !strings.HasSuffix(warnings[0], "models.go:15:2: BornAt is now nihil.NilTime, which omitempty does not omit; "+
+		"null is written where the field was left out, use omitzero to keep leaving it out") {
+		t.Errorf("Expected a warning for the omitempty tag of BornAt, got %q", warnings[0])
+	}
+	if !strings.HasSuffix(warnings[1], "models.go:49:11: Score is now nihil.NilFloat64; update this use by hand") {
+		t.Errorf("Expected a warning for the pointer use of Score, got %q", warnings[1])
+	}
+}
+
+func TestMigrate_NothingToDo(t *testing.T) {
+	changes, warnings, err := migrate("testdata/models", "github.com/mrrizkin/nihil/cmd/nihil-migrate")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 0 || len(warnings) != 0 {
+		t.Errorf("Expected no changes, got %d changes and warnings %q", len(changes), warnings)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		expected string
+	}{
+		{
+			name:     "changed line",
+			old:      "a\nb\nc\n",
+			new:      "a\nB\nc\n",
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:     "separate hunks",
+			old:      "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			new:      "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -8,4 +9,3 @@\n 8\n 9\n 10\n-11\n",
+		},
+		{
+			name:     "identical",
+			old:      "a\n",
+			new:      "a\n",
+			expected: "--- a/f.go\n+++ b/f.go\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := unifiedDiff("f.go", []byte(tt.old), []byte(tt.new))
+			if result != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	// A long file changed at both ends, as when imports and a trailing
+	// struct are rewritten
+	var a []string
+	for i := range 50000 {
+		a = append(a, strconv.Itoa(i)+"\n")
+	}
+	b := append([]string{"import\n"}, a[1:len(a)-1]...)
+	b = append(b, "end\n", "more\n")
+
+	ops := diffLines(a, b)
+	var oldLines, newLines []string
+	changes := 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldLines = append(oldLines, op.line)
+		}
+		if op.kind != '-' {
+			newLines = append(newLines, op.line)
+		}
+		if op.kind != ' ' {
+			changes++
+		}
+	}
+	if !slices.Equal(oldLines, a) || !slices.Equal(newLines, b) {
+		t.Fatal("Expected the edit script to turn the old lines into the new ones")
+	}
+	if changes != 5 {
+		t.Errorf("Expected 5 changed lines, got %d", changes)
+	}
+}
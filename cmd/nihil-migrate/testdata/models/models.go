@@ -0,0 +1,50 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// User is a row of the users table
+type User struct {
+	ID       int64
+	Email    sql.NullString // login address
+	Age      sql.NullInt32
+	Nickname *string `json:"nickname"`
+	Score    *float64
+	BornAt   *time.Time `json:"born_at,omitempty"`
+	Tags     []string
+}
+
+func NewUser(email string) User {
+	return User{
+		Email: sql.NullString{String: email, Valid: true},
+		Age:   sql.NullInt32{},
+	}
+}
+
+// Greeting uses the nickname when there is one
+func (u *User) Greeting() string {
+	if u.Nickname != nil {
+		return "Hi " + *u.Nickname
+	}
+	if nil == u.Nickname || !u.Email.Valid {
+		return "Hi"
+	}
+	return "Hi " + u.Email.String
+}
+
+func (u *User) Rename(name string) {
+	u.Nickname = &name
+	u.BornAt = nil
+	*u.Score = 1.5
+}
+
+func (u *User) Clear() {
+	u.Nickname = nil
+	u.Age = sql.NullInt32{Int32: 0, Valid: false}
+}
+
+func scoreOf(u *User) *float64 {
+	return u.Score
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	nihilPath     = "github.com/mrrizkin/nihil"
+	nihilgormPath = "github.com/mrrizkin/nihil/nihilgorm"
+)
+
+// nihilTypes maps the nihil types to the TypeScript type of their value
+var nihilTypes = map[string]string{
+	"NilByte":    "number",
+	"NilBool":    "boolean",
+	"NilFloat64": "number",
+	"NilInt16":   "number",
+	"NilInt32":   "number",
+	"NilInt64":   "number",
+	"NilString":  "string",
+	"NilTime":    "string",
+}
+
+// generator emits TypeScript interfaces for the structs of loaded packages
+type generator struct {
+	pkgs    []*packages.Package
+	emitted map[*types.TypeName]string // struct types with an interface, by name
+	queue   []*types.TypeName          // struct types waiting to be emitted
+	out     strings.Builder
+}
+
+// generate loads the packages matching patterns and returns TypeScript
+// interfaces for their exported structs with JSON tags, and for every
+// struct those refer to
+func generate(dir string, patterns ...string) (string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedDeps | packages.NeedImports,
+		Dir:  dir,
+	}, patterns...)
+	if err != nil {
+		return "", err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return "", fmt.Errorf("nihil-ts: failed to load %s", strings.Join(patterns, " "))
+	}
+
+	g := &generator{pkgs: pkgs, emitted: map[*types.TypeName]string{}}
+	for _, pkg := range pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !obj.Exported() || obj.IsAlias() {
+				continue
+			}
+			if st, ok := obj.Type().Underlying().(*types.Struct); ok && hasJSONTags(st) {
+				if err := g.enqueue(obj); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	g.out.WriteString("// Code generated by nihil-ts. DO NOT EDIT.\n")
+	for len(g.queue) > 0 {
+		obj := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.emit(obj); err != nil {
+			return "", err
+		}
+	}
+	return g.out.String(), nil
+}
+
+// hasJSONTags reports whether any field of st has a json tag
+func hasJSONTags(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup("json"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue schedules the interface of a named struct type, unless it is
+// already emitted or scheduled
+func (g *generator) enqueue(obj *types.TypeName) error {
+	if _, ok := g.emitted[obj]; ok {
+		return nil
+	}
+	for other, name := range g.emitted {
+		if name == obj.Name() {
+			return fmt.Errorf("nihil-ts: %s and %s would both be named %s",
+				other.Pkg().Path()+"."+other.Name(), obj.Pkg().Path()+"."+obj.Name(), name)
+		}
+	}
+	g.emitted[obj] = obj.Name()
+	g.queue = append(g.queue, obj)
+	return nil
+}
+
+// tsField is one property of an emitted interface
+type tsField struct {
+	name     string
+	typ      string
+	optional bool
+}
+
+// emit writes the interface of a named struct type
+func (g *generator) emit(obj *types.TypeName) error {
+	st := obj.Type().Underlying().(*types.Struct)
+	var fields []tsField
+	if err := g.collectFields(st, map[string]bool{}, &fields); err != nil {
+		return fmt.Errorf("nihil-ts: %s.%s: %w", obj.Pkg().Path(), obj.Name(), err)
+	}
+
+	fmt.Fprintf(&g.out, "\nexport interface %s {\n", g.emitted[obj])
+	for _, f := range fields {
+		name := f.name
+		if !isIdentifier(name) {
+			name = strconv.Quote(name)
+		}
+		if f.optional {
+			name += "?"
+		}
+		fmt.Fprintf(&g.out, "  %s: %s;\n", name, f.typ)
+	}
+	g.out.WriteString("}\n")
+	return nil
+}
+
+// collectFields appends the JSON-visible fields of st to fields, following
+// the encoding/json rules for names, "-" and untagged embedded structs
+func (g *generator) collectFields(st *types.Struct, seen map[string]bool, fields *[]tsField) error {
+	var embedded []*types.Struct
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if v.Embedded() && name == "" {
+			ft := v.Type()
+			if p, ok := ft.(*types.Pointer); ok {
+				ft = p.Elem()
+			}
+			if est, ok := ft.Underlying().(*types.Struct); ok && !isNihil(ft) {
+				embedded = append(embedded, est)
+				continue
+			}
+		}
+		if !v.Exported() {
+			continue
+		}
+		if name == "" {
+			name = v.Name()
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		opts := strings.Split(options, ",")
+		typ, err := g.tsType(v.Type(), slices.Contains(opts, "string"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+		_, isOptional := optionalValue(v.Type())
+		*fields = append(*fields, tsField{
+			name: name,
+			typ:  typ,
+			optional: isOptional || slices.Contains(opts, "omitzero") ||
+				slices.Contains(opts, "omitempty") && omitsEmpty(v.Type()),
+		})
+	}
+
+	// Promoted fields lose to fields declared on the outer struct
+	for _, est := range embedded {
+		if err := g.collectFields(est, seen, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// omitsEmpty reports whether omitempty can drop a field of type t;
+// encoding/json never treats a struct as empty
+func omitsEmpty(t types.Type) bool {
+	_, isStruct := t.Underlying().(*types.Struct)
+	return !isStruct
+}
+
+// tsType returns the TypeScript type of the JSON encoding of t. quoted is
+// set for fields with the ",string" option.
+func (g *generator) tsType(t types.Type, quoted bool) (string, error) {
+	if value, ok := optionalValue(t); ok {
+		// Optional encodes its value, and decodes null too
+		typ, err := g.tsType(value, false)
+		if err != nil {
+			return "", err
+		}
+		return orNull(typ), nil
+	}
+	if name, ok := nihilName(t); ok {
+		return g.nihilType(name, t)
+	}
+
+	// json.RawMessage is an alias in newer Go releases, so match it first
+	if isType(t, "encoding/json", "RawMessage") {
+		return "unknown", nil
+	}
+	t = types.Unalias(t)
+
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		switch {
+		case isType(t, "time", "Time"):
+			return "string", nil
+		case hasMethod(t, "MarshalJSON"):
+			return "unknown", nil
+		case hasMethod(t, "MarshalText"):
+			return "string", nil
+		}
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			if named.TypeArgs().Len() > 0 {
+				return "", fmt.Errorf("generic type %s is not supported", named)
+			}
+			if err := g.enqueue(obj); err != nil {
+				return "", err
+			}
+			return g.emitted[obj], nil
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return quotedType("boolean", quoted), nil
+		case u.Info()&types.IsNumeric != 0:
+			return quotedType("number", quoted), nil
+		case u.Info()&types.IsString != 0:
+			return "string", nil
+		}
+	case *types.Pointer:
+		elem, err := g.tsType(u.Elem(), quoted)
+		if err != nil {
+			return "", err
+		}
+		return orNull(elem), nil
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			return "string | null", nil // base64
+		}
+		elem, err := g.tsType(u.Elem(), false)
+		if err != nil {
+			return "", err
+		}
+		return arrayOf(elem) + " | null", nil
+	case *types.Array:
+		elem, err := g.tsType(u.Elem(), false)
+		if err != nil {
+			return "", err
+		}
+		return arrayOf(elem), nil
+	case *types.Map:
+		elem, err := g.tsType(u.Elem(), false)
+		if err != nil {
+			return "", err
+		}
+		return "Record<string, " + elem + "> | null", nil
+	case *types.Interface:
+		return "unknown", nil
+	case *types.Struct:
+		return "", fmt.Errorf("anonymous struct types are not supported")
+	}
+	return "", fmt.Errorf("type %s has no JSON encoding", t)
+}
+
+// nihilType returns the TypeScript type of a nihil type
+func (g *generator) nihilType(name string, t types.Type) (string, error) {
+	if name == "NilEnum" {
+		return g.enumType(t)
+	}
+	return nihilTypes[name] + " | null", nil
+}
+
+// enumType returns a union of the string constants declared with the enum
+// type of a NilEnum, or string when it has none
+func (g *generator) enumType(t types.Type) (string, error) {
+	named := unwrapNihil(t).(*types.Named)
+	enum, ok := named.TypeArgs().At(0).(*types.Named)
+	if !ok || enum.Obj().Pkg() == nil {
+		return "string | null", nil
+	}
+
+	var values []string
+	scope := enum.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && types.Identical(c.Type(), enum) && c.Val().Kind() == constant.String {
+			values = append(values, strconv.Quote(constant.StringVal(c.Val())))
+		}
+	}
+	if len(values) == 0 {
+		return "string | null", nil
+	}
+	slices.Sort(values)
+	return strings.Join(slices.Compact(values), " | ") + " | null", nil
+}
+
+// nihilName returns the name of the nihil type t is or wraps
+func nihilName(t types.Type) (string, bool) {
+	named, ok := unwrapNihil(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != nihilPath {
+		return "", false
+	}
+	name := named.Obj().Name()
+	if _, ok := nihilTypes[name]; ok || name == "NilEnum" {
+		return name, true
+	}
+	return "", false
+}
+
+// optionalValue returns T when t is nihil.Optional[T]
+func optionalValue(t types.Type) (types.Type, bool) {
+	t = types.Unalias(t)
+	if !isType(t, nihilPath, "Optional") {
+		return nil, false
+	}
+	return t.(*types.Named).TypeArgs().At(0), true
+}
+
+// unwrapNihil returns the nihil type embedded as the first field of a
+// struct type, such as the nihilgorm wrapper types, or t itself
+func unwrapNihil(t types.Type) types.Type {
+	for {
+		t = types.Unalias(t)
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok || st.NumFields() == 0 || !st.Field(0).Embedded() {
+			return t
+		}
+		inner := st.Field(0).Type()
+		if named, ok := inner.(*types.Named); !ok || named.Obj().Pkg() == nil ||
+			(named.Obj().Pkg().Path() != nihilPath && named.Obj().Pkg().Path() != nihilgormPath) {
+			return t
+		}
+		t = inner
+	}
+}
+
+// isType reports whether t is the named type or alias path.name
+func isType(t types.Type, path, name string) bool {
+	var obj *types.TypeName
+	switch t := t.(type) {
+	case *types.Alias:
+		obj = t.Obj()
+	case *types.Named:
+		obj = t.Obj()
+	default:
+		return false
+	}
+	return obj.Pkg() != nil && obj.Pkg().Path() == path && obj.Name() == name
+}
+
+// isNihil reports whether t is or wraps a nihil type
+func isNihil(t types.Type) bool {
+	_, ok := nihilName(t)
+	return ok
+}
+
+// hasMethod reports whether t or *t has a method of the given name
+func hasMethod(t types.Type, name string) bool {
+	for _, typ := range []types.Type{t, types.NewPointer(t)} {
+		mset := types.NewMethodSet(typ)
+		for i := 0; i < mset.Len(); i++ {
+			if mset.At(i).Obj().Name() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func quotedType(typ string, quoted bool) string {
+	if quoted {
+		return "string"
+	}
+	return typ
+}
+
+func orNull(typ string) string {
+	if strings.HasSuffix(typ, " | null") || typ == "unknown" {
+		return typ
+	}
+	return typ + " | null"
+}
+
+func arrayOf(elem string) string {
+	if strings.Contains(elem, " ") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+// isIdentifier reports whether name can be used as a property name unquoted
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '$' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
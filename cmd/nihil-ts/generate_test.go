@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestGenerate(t *testing.T) {
+	ts, err := generate("testdata/api", ".")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `// Code generated by nihil-ts. DO NOT EDIT.
+
+export interface Base {
+  id: number;
+  created_at: string;
+}
+
+export interface User {
+  email: string | null;
+  nickname?: string | null;
+  bio: string | null;
+  birthday: string | null;
+  balance: number | null;
+  age: number | null;
+  active: boolean | null;
+  status: "active" | "inactive" | null;
+  name: string | null;
+  deleted_at: string | null;
+  address?: Address | null;
+  tags: string[] | null;
+  scores: Record<string, number | null> | null;
+  extra: unknown;
+  count: string;
+  id: number;
+  created_at: string;
+}
+
+export interface UserPatch {
+  email?: string | null;
+  age?: number | null;
+  tags?: string[] | null;
+}
+
+export interface Address {
+  Street: string | null;
+  Zip: string;
+}
+`
+	if ts != expected {
+		t.Errorf("Unexpected output:\n%s", ts)
+	}
+}
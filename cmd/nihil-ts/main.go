@@ -0,0 +1,58 @@
+// Command nihil-ts generates TypeScript interfaces for the Go structs of an
+// API, so front-end types match the JSON the server sends.
+//
+// Usage:
+//
+//	nihil-ts [-o api.ts] [packages]
+//
+// Every exported struct with json tags in the given packages (default ".")
+// becomes an interface, along with the structs its fields refer to. Fields
+// follow the encoding/json rules for names, "-", ",string" and embedded
+// structs:
+//
+//	nihil.NilString           string | null
+//	nihil.NilTime             string | null (RFC 3339)
+//	nihil.NilInt64            number | null
+//	nihil.NilEnum[Status]     "active" | "inactive" | null, from Status constants
+//	*T, []T, map[string]T     T | null, T[] | null, Record<string, T> | null
+//	`json:",omitzero"`        optional (name?: T), as are omitempty fields
+//	                          of types omitempty can drop
+//
+// The nihilgorm wrapper types map like the nihil types they embed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	output := flag.String("o", "", "write to `file` instead of standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: nihil-ts [flags] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*output, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, patterns []string) error {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	ts, err := generate("", patterns...)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = os.Stdout.WriteString(ts)
+		return err
+	}
+	return os.WriteFile(output, []byte(ts), 0o644)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/nihilgorm"
+)
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+type Base struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type User struct {
+	Base
+	Email     nihil.NilString           `json:"email"`
+	Nickname  nihil.NilString           `json:"nickname,omitzero"`
+	Bio       nihil.NilString           `json:"bio,omitempty"`
+	Birthday  nihil.NilTime             `json:"birthday"`
+	Balance   nihil.NilInt64            `json:"balance"`
+	Age       nihil.NilInt32            `json:"age"`
+	Active    nihil.NilBool             `json:"active"`
+	Status    nihil.NilEnum[Status]     `json:"status"`
+	Name      nihilgorm.NilString       `json:"name"`
+	DeletedAt nihilgorm.DeletedAt       `json:"deleted_at"`
+	Address   *Address                  `json:"address,omitempty"`
+	Tags      []string                  `json:"tags"`
+	Scores    map[string]nihil.NilInt32 `json:"scores"`
+	Extra     json.RawMessage           `json:"extra"`
+	Count     int                       `json:"count,string"`
+	Secret    string                    `json:"-"`
+	internal  string
+}
+
+// UserPatch is a partial update of a User
+type UserPatch struct {
+	Email nihil.Optional[nihil.NilString] `json:"email,omitzero"`
+	Age   nihil.Optional[int]             `json:"age,omitzero"`
+	Tags  nihil.Optional[[]string]        `json:"tags"`
+}
+
+// Address has no json tags but is referenced by User
+type Address struct {
+	Street nihil.NilString
+	Zip    string
+}
+
+// unexported is skipped
+type unexported struct {
+	Value string `json:"value"`
+}
@@ -0,0 +1,14 @@
+// Command nihil-vet runs the nihil analyzers, standalone or under go vet:
+//
+//	nihil-vet ./...
+//	go vet -vettool=$(which nihil-vet) ./...
+//
+// See package github.com/mrrizkin/nihil/analysis for what they report.
+package main
+
+import (
+	"github.com/mrrizkin/nihil/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() { multichecker.Main(analysis.Analyzers...) }
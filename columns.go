@@ -0,0 +1,108 @@
+package nihil
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// columnName returns the database column of a struct field: the GORM
+// column setting, else the name in a `db:"..."` tag, else the field name in
+// snake_case. The second result is false for fields tagged `db:"-"`,
+// `gorm:"-"` or `gorm:"-:all"`.
+func columnName(sf reflect.StructField) (string, bool) {
+	if ignored, _ := gormIgnored(sf.Tag); ignored {
+		return "", false
+	}
+	if name := gormSettingValue(sf.Tag, "column"); name != "" {
+		return name, true
+	}
+	if db, ok := sf.Tag.Lookup("db"); ok {
+		name, _, _ := strings.Cut(db, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return snakeCase(sf.Name), true
+}
+
+// gormIgnored reports whether a gorm tag ignores a field the way GORM
+// does: entirely for `-` and `-:all`, and only when migrating for
+// `-:migration`
+func gormIgnored(tag reflect.StructTag) (ignored, ignoredInMigration bool) {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		k, v, _ := strings.Cut(setting, ":")
+		if strings.TrimSpace(k) != "-" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "all":
+			return true, true
+		case "migration":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// gormSettingValue returns the value of a `gorm:"key:value"` setting
+func gormSettingValue(tag reflect.StructTag, key string) string {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		k, v, _ := strings.Cut(setting, ":")
+		if strings.EqualFold(strings.TrimSpace(k), key) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// gormSetting reports whether a gorm tag has the given setting
+func gormSetting(tag reflect.StructTag, key string) bool {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		k, _, _ := strings.Cut(setting, ":")
+		if strings.EqualFold(strings.TrimSpace(k), key) {
+			return true
+		}
+	}
+	return false
+}
+
+// gormSettings parses a gorm tag the way GORM does, into upper-cased keys
+// mapped to their values; bare flags such as `not null` map to their key
+func gormSettings(tag reflect.StructTag) map[string]string {
+	settings := map[string]string{}
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		k, v, ok := strings.Cut(setting, ":")
+		k = strings.ToUpper(strings.TrimSpace(k))
+		if k == "" {
+			continue
+		}
+		if !ok {
+			v = k
+		}
+		settings[k] = strings.TrimSpace(v)
+	}
+	return settings
+}
+
+// snakeCase converts a Go field name to snake_case the way GORM names
+// columns, keeping initialisms together: "UserID" becomes "user_id" and
+// "HTTPStatus" becomes "http_status"
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
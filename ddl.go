@@ -0,0 +1,214 @@
+package nihil
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// plainKinds maps non-nihil field types to the kind whose column type they
+// share; they are NOT NULL columns
+var plainKinds = map[reflect.Kind]Kind{
+	reflect.Bool:    KindBool,
+	reflect.Int8:    KindInt16,
+	reflect.Int16:   KindInt16,
+	reflect.Int32:   KindInt32,
+	reflect.Int:     KindInt64,
+	reflect.Int64:   KindInt64,
+	reflect.Uint8:   KindByte,
+	reflect.Uint16:  KindInt32,
+	reflect.Uint32:  KindInt64,
+	reflect.Float32: KindFloat64,
+	reflect.Float64: KindFloat64,
+	reflect.String:  KindString,
+}
+
+// CreateTableSQL returns the CREATE TABLE statement of a model struct on a
+// dialect, without a database connection:
+//
+//	stmt, err := nihil.CreateTableSQL("postgres", User{})
+//	// CREATE TABLE "users" (
+//	//   "id" BIGINT NOT NULL,
+//	//   "email" VARCHAR(100) NULL,
+//	//   PRIMARY KEY ("id")
+//	// );
+//
+// Column types come from the dialect registry, as for the nihilgorm types,
+// with the GORM size, precision and other settings applied; a `gorm:"type:..."`
+// setting is used verbatim. Nihil fields and pointers are NULL, other
+// fields NOT NULL unless tagged `gorm:"not null"`; plain Go types share the
+// mapping of their nihil counterpart, e.g. string that of NilString.
+// Columns are named as in UpdateMap, the primary key is the fields tagged
+// `gorm:"primaryKey"` or else the ID field, and `gorm:"default:..."` adds a
+// DEFAULT clause. The table is named by a TableName method, else the
+// plural snake_case type name, as GORM names it for regular plurals; give
+// models with irregular or uncountable names, such as Person, a TableName
+// method. Indexes, foreign keys and auto-increment are left to the
+// migration.
+//
+// Fields of other types without a type setting are an error; tag them
+// `db:"-"` or `gorm:"-"` to leave them out. That includes uint and uint64,
+// whose range no BIGINT holds: give them a type such as `gorm:"type:numeric(20)"`. Like GORM's migrator, fields
+// tagged `gorm:"-:migration"` are left out too.
+func CreateTableSQL(dialect string, model any) (string, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || isNullable(t) {
+		return "", fmt.Errorf("nihil: CreateTableSQL expects a struct, got %T", model)
+	}
+	table, ok := tableName(t)
+	if !ok {
+		return "", fmt.Errorf("nihil: CreateTableSQL needs a named struct or a TableName method, got %s", t)
+	}
+
+	quote, _ := sqlSyntax(dialect)
+	var lines, primary []string
+	var idColumn string
+	err := visitColumns(t, map[string]bool{}, func(sf reflect.StructField, name string) error {
+		definition, err := columnDefinition(dialect, quote, sf, name)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, definition)
+		if gormSetting(sf.Tag, "primaryKey") || gormSetting(sf.Tag, "primary_key") {
+			primary = append(primary, quote(name))
+		}
+		if sf.Name == "ID" {
+			idColumn = name
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", fmt.Errorf("nihil: %s has no columns", t)
+	}
+
+	if len(primary) == 0 && idColumn != "" {
+		primary = []string{quote(idColumn)}
+	}
+	if len(primary) > 0 {
+		lines = append(lines, "PRIMARY KEY ("+strings.Join(primary, ", ")+")")
+	}
+	return "CREATE TABLE " + quote(table) + " (\n  " + strings.Join(lines, ",\n  ") + "\n);", nil
+}
+
+// visitColumns calls fn for every column field of t in field order, with
+// fields of embedded structs in place and outer fields winning over
+// promoted ones of the same column
+func visitColumns(t reflect.Type, seen map[string]bool, fn func(sf reflect.StructField, name string) error) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && isEmbeddedColumns(sf.Type) {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if err := visitColumns(ft, seen, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ignored := gormIgnored(sf.Tag); ignored || !sf.IsExported() {
+			continue
+		}
+		name, ok := columnName(sf)
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if err := fn(sf, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnDefinition returns the definition of the column of sf in a CREATE
+// TABLE statement
+func columnDefinition(dialect string, quote func(string) string, sf reflect.StructField, name string) (string, error) {
+	settings := gormSettings(sf.Tag)
+
+	ft := sf.Type
+	nullable := false
+	if ft.Kind() == reflect.Pointer {
+		ft, nullable = ft.Elem(), true
+	}
+
+	column := Column{Name: name, Settings: settings, Tag: sf.Tag, Quote: quote}
+	kind, ok := kindOf(ft)
+	if ok {
+		nullable = true
+		if kind == KindEnum {
+			column.Values = reflect.Zero(ft).Interface().(interface{ RegisteredValues() []string }).RegisteredValues()
+		}
+	} else {
+		kind, ok = plainKind(ft)
+	}
+	if _, notNull := settings["NOT NULL"]; notNull {
+		nullable = false
+	}
+
+	typ := settings["TYPE"]
+	if typ == "" && ok {
+		typ = columnType(dialect, kind, column, nullable)
+	}
+	if typ == "" {
+		return "", fmt.Errorf("nihil: field %s has no column type for %s; set `gorm:\"type:...\"` or tag it `db:\"-\"`", sf.Name, sf.Type)
+	}
+
+	definition := quote(name) + " " + typ
+	if nullable {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
+	if value, ok := settings["DEFAULT"]; ok {
+		definition += " DEFAULT " + value
+	}
+	return definition, nil
+}
+
+// plainKind returns the kind whose column type a non-nihil type shares
+func plainKind(t reflect.Type) (Kind, bool) {
+	if t == reflect.TypeFor[time.Time]() {
+		return KindTime, true
+	}
+	if t.Implements(reflect.TypeFor[driver.Valuer]()) || reflect.PointerTo(t).Implements(reflect.TypeFor[driver.Valuer]()) {
+		return 0, false // stored as whatever Value returns
+	}
+	kind, ok := plainKinds[t.Kind()]
+	return kind, ok
+}
+
+// tableName returns the table of a model: its TableName method, else the
+// plural snake_case type name, and false for an unnamed type without one
+func tableName(t reflect.Type) (string, bool) {
+	type tabler interface{ TableName() string }
+	if m, ok := reflect.New(t).Interface().(tabler); ok {
+		name := m.TableName()
+		return name, name != ""
+	}
+	if t.Name() == "" {
+		return "", false
+	}
+	return plural(snakeCase(t.Name())), true
+}
+
+// plural returns the regular English plural of a snake_case name: boxes,
+// categories, users. Irregular and uncountable nouns are left to TableName.
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case len(name) > 1 && name[len(name)-1] == 'y' && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
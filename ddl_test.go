@@ -0,0 +1,171 @@
+package nihil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type ddlTimestamps struct {
+	CreatedAt time.Time
+	DeletedAt NilTime `gorm:"index"`
+}
+
+type ddlUser struct {
+	ID       int64
+	Email    NilString           `gorm:"size:100"`
+	Name     string              `gorm:"size:50;default:'anonymous'"`
+	Status   NilEnum[testStatus] `gorm:"not null"`
+	Score    *float64            `db:"points"`
+	Bio      NilString           `gorm:"type:JSONB"`
+	SeenAt   NilTime             `gorm:"precision:6" nihil:"storage=unixmilli"`
+	Secret   string              `db:"-"`
+	Friends  []ddlUser           `gorm:"-"`
+	Rank     int64               `gorm:"-:migration"`
+	Cache    string              `gorm:"-:all"`
+	internal int
+	ddlTimestamps
+}
+
+type ddlMembership struct {
+	UserID  int64  `gorm:"primaryKey"`
+	GroupID int64  `gorm:"primaryKey"`
+	Role    string `gorm:"size:20"`
+}
+
+func (ddlMembership) TableName() string { return "user_groups" }
+
+func TestCreateTableSQL(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{"postgres", `CREATE TABLE "ddl_users" (
+  "id" BIGINT NOT NULL,
+  "email" VARCHAR(100) NULL,
+  "name" VARCHAR(50) NOT NULL DEFAULT 'anonymous',
+  "status" TEXT CHECK ("status" IN ('active', 'inactive')) NOT NULL,
+  "points" DOUBLE PRECISION NULL,
+  "bio" JSONB NULL,
+  "seen_at" BIGINT NULL,
+  "created_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+  "deleted_at" TIMESTAMP WITH TIME ZONE NULL,
+  PRIMARY KEY ("id")
+);`},
+		{"mysql", "CREATE TABLE `ddl_users` (\n" +
+			"  `id` BIGINT NOT NULL,\n" +
+			"  `email` VARCHAR(100) NULL,\n" +
+			"  `name` VARCHAR(50) NOT NULL DEFAULT 'anonymous',\n" +
+			"  `status` ENUM('active', 'inactive') NOT NULL,\n" +
+			"  `points` DOUBLE NULL,\n" +
+			"  `bio` JSONB NULL,\n" +
+			"  `seen_at` BIGINT NULL,\n" +
+			"  `created_at` DATETIME NOT NULL,\n" +
+			"  `deleted_at` DATETIME NULL,\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			");"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			got, err := CreateTableSQL(tt.dialect, &ddlUser{})
+			if err != nil {
+				t.Fatalf("CreateTableSQL failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCreateTableSQL_PrimaryKeyAndTableName(t *testing.T) {
+	expected := `CREATE TABLE "user_groups" (
+  "user_id" BIGINT NOT NULL,
+  "group_id" BIGINT NOT NULL,
+  "role" NVARCHAR(20) NOT NULL,
+  PRIMARY KEY ("user_id", "group_id")
+);`
+	got, err := CreateTableSQL("sqlserver", ddlMembership{})
+	if err != nil {
+		t.Fatalf("CreateTableSQL failed: %v", err)
+	}
+	if got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestCreateTableSQL_NullableDialect(t *testing.T) {
+	RegisterDialect(Dialect{
+		Name:     "clickhouse-ddl-test",
+		Quote:    quoteWith("`", "`"),
+		Nullable: func(columnType string) string { return "Nullable(" + columnType + ")" },
+		Types: map[Kind]TypeMapper{
+			KindInt64:  FixedType("Int64"),
+			KindString: FixedType("String"),
+		},
+	})
+
+	type event struct {
+		ID   int64
+		Note NilString
+	}
+	got, err := CreateTableSQL("clickhouse-ddl-test", event{})
+	if err != nil {
+		t.Fatalf("CreateTableSQL failed: %v", err)
+	}
+	for _, want := range []string{"`id` Int64 NOT NULL", "`note` Nullable(String) NULL"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := map[string]string{
+		"user":        "users",
+		"ddl_user":    "ddl_users",
+		"status":      "statuses",
+		"box":         "boxes",
+		"batch":       "batches",
+		"wish":        "wishes",
+		"category":    "categories",
+		"day":         "days",
+		"order_entry": "order_entries",
+	}
+	for name, expected := range tests {
+		if got := plural(name); got != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, got)
+		}
+	}
+}
+
+func TestCreateTableSQL_Errors(t *testing.T) {
+	type unsupported struct {
+		Tags []string
+	}
+	type unsigned struct {
+		Hits uint64
+	}
+	type empty struct{}
+
+	tests := []struct {
+		model any
+		want  string
+	}{
+		{unsupported{}, "nihil: field Tags has no column type for []string"},
+		{unsigned{}, "nihil: field Hits has no column type for uint64"},
+		{struct{ ID int64 }{}, "needs a named struct or a TableName method"},
+		{empty{}, "has no columns"},
+		{NilString{}, "expects a struct"},
+		{42, "expects a struct"},
+		{nil, "expects a struct"},
+	}
+
+	for _, tt := range tests {
+		_, err := CreateTableSQL("postgres", tt.model)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%T: Expected error containing %q, got %v", tt.model, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package nihil
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Kind identifies a nihil type in a dialect's type mapping
+type Kind int
+
+const (
+	KindByte Kind = iota + 1
+	KindBool
+	KindFloat64
+	KindInt16
+	KindInt32
+	KindInt64
+	KindString
+	KindTime
+	KindEnum
+)
+
+var kindNames = map[Kind]string{
+	KindByte:    "NilByte",
+	KindBool:    "NilBool",
+	KindFloat64: "NilFloat64",
+	KindInt16:   "NilInt16",
+	KindInt32:   "NilInt32",
+	KindInt64:   "NilInt64",
+	KindString:  "NilString",
+	KindTime:    "NilTime",
+	KindEnum:    "NilEnum",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+var nullableKinds = map[reflect.Type]Kind{
+	reflect.TypeFor[NilByte]():    KindByte,
+	reflect.TypeFor[NilBool]():    KindBool,
+	reflect.TypeFor[NilFloat64](): KindFloat64,
+	reflect.TypeFor[NilInt16]():   KindInt16,
+	reflect.TypeFor[NilInt32]():   KindInt32,
+	reflect.TypeFor[NilInt64]():   KindInt64,
+	reflect.TypeFor[NilString]():  KindString,
+	reflect.TypeFor[NilTime]():    KindTime,
+}
+
+// kindOf returns the kind of a nihil type, or of a struct type embedding
+// one as its first field, such as the nihilgorm wrapper types
+func kindOf(t reflect.Type) (Kind, bool) {
+	for t.Kind() == reflect.Struct && t.NumField() > 0 && t.Field(0).Anonymous && isNullable(t.Field(0).Type) {
+		t = t.Field(0).Type
+	}
+	if kind, ok := nullableKinds[t]; ok {
+		return kind, true
+	}
+	if isNullable(t) && t.Implements(registeredValuesType) {
+		return KindEnum, true
+	}
+	return 0, false
+}
+
+// Column describes the column a nihil field is stored in
+type Column struct {
+	Dialect  string              // name of the dialect being mapped
+	Name     string              // column name, for constraints that refer to it
+	Settings map[string]string   // upper-cased GORM tag settings, e.g. "SIZE": "100"
+	Tag      reflect.StructTag   // full struct tag, for `nihil:"..."` options
+	Values   []string            // allowed values of a NilEnum column
+	Quote    func(string) string // identifier quoting; defaults to the dialect's
+}
+
+// QuotedName returns the column name quoted for the dialect
+func (c Column) QuotedName() string {
+	return c.Quote(c.Name)
+}
+
+// TypeMapper returns the column type for a nihil type on one dialect
+type TypeMapper func(c Column) string
+
+// Dialect maps every nihil type to its column type on one database
+//
+// Kinds missing from Types fall back to the "default" dialect. Nullable,
+// when set, wraps every column type, for databases such as ClickHouse
+// where columns are NOT NULL unless declared Nullable(...). Placeholder
+// returns the bind parameter for the n-th argument, starting at 1; nil
+// means "?".
+type Dialect struct {
+	Name        string
+	Types       map[Kind]TypeMapper
+	Quote       func(string) string
+	Nullable    func(columnType string) string
+	Placeholder func(n int) string
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{}
+)
+
+// RegisterDialect adds d to the registry, replacing any dialect of the same
+// name, including the built-in "mysql", "postgres", "sqlite", "sqlserver"
+// and "default" ones
+func RegisterDialect(d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	d.Types = maps.Clone(d.Types)
+	dialects[d.Name] = d
+}
+
+// RegisterColumnType overrides the mapping of one kind on a dialect,
+// registering the dialect if it is not known yet
+func RegisterColumnType(dialect string, kind Kind, mapper TypeMapper) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	d := dialects[dialect]
+	d.Name = dialect
+	d.Types = maps.Clone(d.Types)
+	if d.Types == nil {
+		d.Types = map[Kind]TypeMapper{}
+	}
+	d.Types[kind] = mapper
+	dialects[dialect] = d
+}
+
+// LookupDialect returns the registered dialect with the given name
+func LookupDialect(name string) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	d, ok := dialects[name]
+	return d, ok
+}
+
+// ColumnType returns the column type of a nihil type on a dialect
+//
+// NilTime columns with an integer or text storage mode are mapped as
+// NilInt64 and NilString columns, and NilEnum columns without registered
+// values as NilString columns.
+func ColumnType(dialect string, kind Kind, c Column) string {
+	return columnType(dialect, kind, c, true)
+}
+
+// columnType maps a column, wrapping it with the dialect's Nullable only
+// when nullable is true
+func columnType(dialect string, kind Kind, c Column, nullable bool) string {
+	c.Dialect = dialect
+
+	switch {
+	case kind == KindTime:
+		if storage, err := FieldTimeStorage(c.Tag); err == nil && storage.IsInteger() {
+			kind = KindInt64
+		} else if err == nil && storage == StorageText {
+			kind = KindString
+			c.Settings = maps.Clone(c.Settings)
+			if c.Settings == nil {
+				c.Settings = map[string]string{}
+			}
+			c.Settings["SIZE"] = "35"
+		}
+	case kind == KindEnum && len(c.Values) == 0:
+		kind = KindString
+	}
+
+	d, _ := LookupDialect(dialect)
+	fallback, _ := LookupDialect("default")
+
+	if c.Quote == nil {
+		c.Quote = d.Quote
+	}
+	if c.Quote == nil {
+		c.Quote = fallback.Quote
+	}
+
+	mapper := d.Types[kind]
+	if mapper == nil {
+		mapper = fallback.Types[kind]
+	}
+	if mapper == nil {
+		return ""
+	}
+
+	mapped := mapper(c)
+	if nullable && d.Nullable != nil {
+		mapped = d.Nullable(mapped)
+	}
+	return mapped
+}
+
+// FixedType maps to the same column type regardless of tag settings
+func FixedType(columnType string) TypeMapper {
+	return func(Column) string { return columnType }
+}
+
+// SizedType maps to sized, with "%s" replaced by the `size` tag setting,
+// when the column has one, and to unsized otherwise
+func SizedType(sized, unsized string) TypeMapper {
+	return settingType("SIZE", sized, unsized)
+}
+
+// PrecisionType maps to precise, with "%s" replaced by the `precision` tag
+// setting, when the column has one, and to imprecise otherwise
+func PrecisionType(precise, imprecise string) TypeMapper {
+	return settingType("PRECISION", precise, imprecise)
+}
+
+func settingType(setting, with, without string) TypeMapper {
+	return func(c Column) string {
+		if value, ok := c.Settings[setting]; ok {
+			return strings.ReplaceAll(with, "%s", value)
+		}
+		return without
+	}
+}
+
+// StringType maps strings tagged `fixed` to fixed and other strings to
+// sized, with "%s" replaced by the `size` tag setting, and strings without
+// a size or tagged `text` to unsized
+func StringType(sized, fixed, unsized string) TypeMapper {
+	return func(c Column) string {
+		size, ok := c.Settings["SIZE"]
+		switch {
+		case !ok || settingEnabled(c, "TEXT"):
+			return unsized
+		case settingEnabled(c, "FIXED"):
+			return strings.ReplaceAll(fixed, "%s", size)
+		}
+		return strings.ReplaceAll(sized, "%s", size)
+	}
+}
+
+// Collated appends the `charset` and `collate` tag settings to the type
+// mapped by mapper, formatted with charset and collate, in which "%s" is
+// replaced by the setting. An empty format ignores the setting.
+func Collated(mapper TypeMapper, charset, collate string) TypeMapper {
+	return func(c Column) string {
+		columnType := mapper(c)
+		if name := c.Settings["CHARSET"]; name != "" && charset != "" {
+			columnType += " " + strings.ReplaceAll(charset, "%s", name)
+		}
+		if name := c.Settings["COLLATE"]; name != "" && collate != "" {
+			columnType += " " + strings.ReplaceAll(collate, "%s", name)
+		}
+		return columnType
+	}
+}
+
+// TimeZoneType maps times to local for columns tagged `timezone:false`
+// and to zoned otherwise
+func TimeZoneType(zoned, local TypeMapper) TypeMapper {
+	return func(c Column) string {
+		if value, ok := c.Settings["TIMEZONE"]; ok && strings.EqualFold(value, "false") {
+			return local(c)
+		}
+		return zoned(c)
+	}
+}
+
+// settingEnabled reports whether a bare flag tag setting such as `fixed`
+// is present and not set to false
+func settingEnabled(c Column, setting string) bool {
+	value, ok := c.Settings[setting]
+	return ok && !strings.EqualFold(value, "false")
+}
+
+// mysqlTextTypes are the MySQL text types selected by the `text` tag setting
+var mysqlTextTypes = map[string]string{
+	"tiny":   "TINYTEXT",
+	"medium": "MEDIUMTEXT",
+	"long":   "LONGTEXT",
+}
+
+// mysqlStringType maps strings tagged `text`, `text:tiny`, `text:medium` or
+// `text:long` to the matching MySQL text type
+func mysqlStringType(c Column) string {
+	if settingEnabled(c, "TEXT") {
+		if textType, ok := mysqlTextTypes[strings.ToLower(c.Settings["TEXT"])]; ok {
+			return textType
+		}
+		return "TEXT"
+	}
+	return StringType("VARCHAR(%s)", "CHAR(%s)", "LONGTEXT")(c)
+}
+
+// CheckedEnumType maps enums to columnType followed by a CHECK constraint
+// listing the allowed values. "%d" in columnType is replaced by the width
+// of the longest value, or the `size` tag setting if larger.
+func CheckedEnumType(columnType string) TypeMapper {
+	return func(c Column) string {
+		width := 1
+		for _, v := range c.Values {
+			width = max(width, utf8.RuneCountInString(v))
+		}
+		if size, err := strconv.Atoi(c.Settings["SIZE"]); err == nil {
+			width = max(width, size)
+		}
+		columnType := strings.ReplaceAll(columnType, "%d", strconv.Itoa(width))
+		return columnType + " CHECK (" + c.QuotedName() + " IN (" + enumList(c.Values) + "))"
+	}
+}
+
+// enumList renders values as a comma separated list of SQL string literals
+func enumList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteWith returns an identifier quoting function for the given delimiters
+func quoteWith(open, close string) func(string) string {
+	return func(name string) string {
+		return open + strings.ReplaceAll(name, close, close+close) + close
+	}
+}
+
+// numberedPlaceholder returns bind parameters such as $1, $2, ...
+func numberedPlaceholder(prefix string) func(int) string {
+	return func(n int) string {
+		return prefix + strconv.Itoa(n)
+	}
+}
+
+// sqlSyntax returns the identifier quoting and bind parameters of a
+// dialect, falling back to the default dialect's
+func sqlSyntax(dialect string) (quote func(string) string, placeholder func(int) string) {
+	d, _ := LookupDialect(dialect)
+	fallback, _ := LookupDialect("default")
+
+	quote, placeholder = d.Quote, d.Placeholder
+	if quote == nil {
+		quote = fallback.Quote
+	}
+	if placeholder == nil {
+		placeholder = fallback.Placeholder
+	}
+	if placeholder == nil {
+		placeholder = func(int) string { return "?" }
+	}
+	return quote, placeholder
+}
+
+func init() {
+	RegisterDialect(Dialect{
+		Name:  "mysql",
+		Quote: quoteWith("`", "`"),
+		Types: map[Kind]TypeMapper{
+			KindByte:    FixedType("TINYINT UNSIGNED"),
+			KindBool:    FixedType("BOOLEAN"),
+			KindFloat64: FixedType("DOUBLE"),
+			KindInt16:   FixedType("SMALLINT"),
+			KindInt32:   FixedType("INT"),
+			KindInt64:   FixedType("BIGINT"),
+			KindString:  Collated(mysqlStringType, "CHARACTER SET %s", "COLLATE %s"),
+			KindTime:    PrecisionType("DATETIME(%s)", "DATETIME"),
+			KindEnum: Collated(func(c Column) string {
+				return "ENUM(" + enumList(c.Values) + ")"
+			}, "CHARACTER SET %s", "COLLATE %s"),
+		},
+	})
+
+	RegisterDialect(Dialect{
+		Name:        "postgres",
+		Quote:       quoteWith(`"`, `"`),
+		Placeholder: numberedPlaceholder("$"),
+		Types: map[Kind]TypeMapper{
+			KindByte:    FixedType("SMALLINT"),
+			KindBool:    FixedType("BOOLEAN"),
+			KindFloat64: FixedType("DOUBLE PRECISION"),
+			KindInt16:   FixedType("SMALLINT"),
+			KindInt32:   FixedType("INTEGER"),
+			KindInt64:   FixedType("BIGINT"),
+			KindString:  Collated(StringType("VARCHAR(%s)", "CHAR(%s)", "TEXT"), "", `COLLATE "%s"`),
+			KindTime: TimeZoneType(
+				PrecisionType("TIMESTAMP(%s) WITH TIME ZONE", "TIMESTAMP WITH TIME ZONE"),
+				PrecisionType("TIMESTAMP(%s)", "TIMESTAMP"),
+			),
+			KindEnum: CheckedEnumType("TEXT"),
+		},
+	})
+
+	RegisterDialect(Dialect{
+		Name:  "sqlite",
+		Quote: quoteWith("`", "`"),
+		Types: map[Kind]TypeMapper{
+			KindByte:    FixedType("INTEGER"),
+			KindBool:    FixedType("BOOLEAN"),
+			KindFloat64: FixedType("REAL"),
+			KindInt16:   FixedType("INTEGER"),
+			KindInt32:   FixedType("INTEGER"),
+			KindInt64:   FixedType("INTEGER"),
+			KindString:  Collated(FixedType("TEXT"), "", "COLLATE %s"),
+			KindTime:    FixedType("DATETIME"),
+			KindEnum:    CheckedEnumType("TEXT"),
+		},
+	})
+
+	RegisterDialect(Dialect{
+		Name:        "sqlserver",
+		Quote:       quoteWith(`"`, `"`),
+		Placeholder: numberedPlaceholder("@p"),
+		Types: map[Kind]TypeMapper{
+			KindByte:    FixedType("TINYINT"),
+			KindBool:    FixedType("BIT"),
+			KindFloat64: FixedType("FLOAT"),
+			KindInt16:   FixedType("SMALLINT"),
+			KindInt32:   FixedType("INT"),
+			KindInt64:   FixedType("BIGINT"),
+			KindString:  Collated(StringType("NVARCHAR(%s)", "NCHAR(%s)", "NVARCHAR(MAX)"), "", "COLLATE %s"),
+			KindTime:    PrecisionType("DATETIME2(%s)", "DATETIME2"),
+			KindEnum:    CheckedEnumType("NVARCHAR(%d)"),
+		},
+	})
+
+	// Fallback for unknown dialects and for kinds a dialect leaves out
+	RegisterDialect(Dialect{
+		Name:  "default",
+		Quote: quoteWith(`"`, `"`),
+		Types: map[Kind]TypeMapper{
+			KindByte:    FixedType("TINYINT"),
+			KindBool:    FixedType("BOOLEAN"),
+			KindFloat64: FixedType("DOUBLE"),
+			KindInt16:   FixedType("SMALLINT"),
+			KindInt32:   FixedType("INT"),
+			KindInt64:   FixedType("BIGINT"),
+			KindString:  StringType("VARCHAR(%s)", "CHAR(%s)", "TEXT"),
+			KindTime:    FixedType("DATETIME"),
+			KindEnum:    CheckedEnumType("VARCHAR(%d)"),
+		},
+	})
+}
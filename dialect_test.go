@@ -0,0 +1,203 @@
+package nihil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnType_BuiltinDialects(t *testing.T) {
+	sized := map[string]string{"SIZE": "100"}
+	precise := map[string]string{"PRECISION": "6"}
+
+	tests := []struct {
+		dialect  string
+		kind     Kind
+		column   Column
+		expected string
+	}{
+		{"mysql", KindByte, Column{}, "TINYINT UNSIGNED"},
+		{"postgres", KindFloat64, Column{}, "DOUBLE PRECISION"},
+		{"sqlserver", KindBool, Column{}, "BIT"},
+		{"sqlite", KindInt16, Column{}, "INTEGER"},
+		{"mysql", KindString, Column{}, "LONGTEXT"},
+		{"mysql", KindString, Column{Settings: sized}, "VARCHAR(100)"},
+		{"sqlite", KindString, Column{Settings: sized}, "TEXT"},
+		{"sqlserver", KindString, Column{Settings: sized}, "NVARCHAR(100)"},
+		{"postgres", KindTime, Column{}, "TIMESTAMP WITH TIME ZONE"},
+		{"postgres", KindTime, Column{Settings: precise}, "TIMESTAMP(6) WITH TIME ZONE"},
+		{"sqlserver", KindTime, Column{Settings: precise}, "DATETIME2(6)"},
+		{"mysql", KindTime, Column{Tag: `nihil:"storage=unixmilli"`}, "BIGINT"},
+		{"mysql", KindTime, Column{Tag: `nihil:"storage=text"`}, "VARCHAR(35)"},
+		{"mysql", KindEnum, Column{Values: []string{"a", "it's"}}, "ENUM('a', 'it''s')"},
+		{"postgres", KindEnum, Column{Name: "status", Values: []string{"on", "off"}}, `TEXT CHECK ("status" IN ('on', 'off'))`},
+		{"sqlserver", KindEnum, Column{Name: "status", Values: []string{"on", "off"}}, `NVARCHAR(3) CHECK ("status" IN ('on', 'off'))`},
+		{"mysql", KindEnum, Column{}, "LONGTEXT"},
+		{"unknown", KindInt32, Column{}, "INT"},
+		{"unknown", KindEnum, Column{Name: "s", Values: []string{"x"}, Settings: map[string]string{"SIZE": "8"}}, `VARCHAR(8) CHECK ("s" IN ('x'))`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+tt.kind.String(), func(t *testing.T) {
+			if got := ColumnType(tt.dialect, tt.kind, tt.column); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestColumnType_ColumnOptions(t *testing.T) {
+	settings := func(pairs ...string) Column {
+		c := Column{Name: "code", Settings: map[string]string{}}
+		for i := 0; i < len(pairs); i += 2 {
+			c.Settings[pairs[i]] = pairs[i+1]
+		}
+		return c
+	}
+
+	tests := []struct {
+		dialect  string
+		kind     Kind
+		column   Column
+		expected string
+	}{
+		{"mysql", KindString, settings("SIZE", "2", "FIXED", "FIXED"), "CHAR(2)"},
+		{"postgres", KindString, settings("SIZE", "2", "FIXED", "FIXED"), "CHAR(2)"},
+		{"sqlserver", KindString, settings("SIZE", "2", "FIXED", "FIXED"), "NCHAR(2)"},
+		{"sqlite", KindString, settings("SIZE", "2", "FIXED", "FIXED"), "TEXT"},
+		{"unknown", KindString, settings("SIZE", "2", "FIXED", "FIXED"), "CHAR(2)"},
+		{"mysql", KindString, settings("SIZE", "2", "FIXED", "false"), "VARCHAR(2)"},
+		{"mysql", KindString, settings("FIXED", "FIXED"), "LONGTEXT"},
+		{"mysql", KindString, settings("TEXT", "medium"), "MEDIUMTEXT"},
+		{"mysql", KindString, settings("TEXT", "TINY"), "TINYTEXT"},
+		{"mysql", KindString, settings("TEXT", "TEXT", "SIZE", "100"), "TEXT"},
+		{"postgres", KindString, settings("TEXT", "medium", "SIZE", "100"), "TEXT"},
+		{"sqlserver", KindString, settings("TEXT", "TEXT", "SIZE", "100"), "NVARCHAR(MAX)"},
+		{"mysql", KindString, settings("SIZE", "100", "CHARSET", "utf8mb4", "COLLATE", "utf8mb4_bin"), "VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin"},
+		{"mysql", KindEnum, Column{Values: []string{"a"}, Settings: map[string]string{"CHARSET": "ascii"}}, "ENUM('a') CHARACTER SET ascii"},
+		{"postgres", KindString, settings("CHARSET", "utf8", "COLLATE", "C"), `TEXT COLLATE "C"`},
+		{"sqlserver", KindString, settings("SIZE", "10", "COLLATE", "Latin1_General_CS_AS"), "NVARCHAR(10) COLLATE Latin1_General_CS_AS"},
+		{"sqlite", KindString, settings("COLLATE", "NOCASE"), "TEXT COLLATE NOCASE"},
+		{"postgres", KindTime, settings("TIMEZONE", "false"), "TIMESTAMP"},
+		{"postgres", KindTime, settings("TIMEZONE", "false", "PRECISION", "3"), "TIMESTAMP(3)"},
+		{"postgres", KindTime, settings("TIMEZONE", "true"), "TIMESTAMP WITH TIME ZONE"},
+		{"mysql", KindTime, settings("TIMEZONE", "false"), "DATETIME"},
+	}
+
+	for _, tt := range tests {
+		if got := ColumnType(tt.dialect, tt.kind, tt.column); got != tt.expected {
+			t.Errorf("%s %s %v: expected %q, got %q", tt.dialect, tt.kind, tt.column.Settings, tt.expected, got)
+		}
+	}
+}
+
+func TestColumnType_CustomQuote(t *testing.T) {
+	column := Column{
+		Name:   "status",
+		Values: []string{"on"},
+		Quote:  func(name string) string { return "[" + name + "]" },
+	}
+	expected := "TEXT CHECK ([status] IN ('on'))"
+	if got := ColumnType("sqlite", KindEnum, column); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestRegisterDialect(t *testing.T) {
+	RegisterDialect(Dialect{
+		Name:     "clickhouse-test",
+		Quote:    quoteWith("`", "`"),
+		Nullable: func(columnType string) string { return "Nullable(" + columnType + ")" },
+		Types: map[Kind]TypeMapper{
+			KindInt64:  FixedType("Int64"),
+			KindString: FixedType("String"),
+			KindTime:   PrecisionType("DateTime64(%s)", "DateTime64(3)"),
+		},
+	})
+
+	tests := []struct {
+		kind     Kind
+		column   Column
+		expected string
+	}{
+		{KindInt64, Column{}, "Nullable(Int64)"},
+		{KindString, Column{Settings: map[string]string{"SIZE": "10"}}, "Nullable(String)"},
+		{KindTime, Column{Settings: map[string]string{"PRECISION": "6"}}, "Nullable(DateTime64(6))"},
+		{KindTime, Column{Tag: `nihil:"storage=unix"`}, "Nullable(Int64)"},
+		{KindBool, Column{}, "Nullable(BOOLEAN)"}, // falls back to "default"
+	}
+
+	for _, tt := range tests {
+		if got := ColumnType("clickhouse-test", tt.kind, tt.column); got != tt.expected {
+			t.Errorf("%v: Expected %q, got %q", tt.kind, tt.expected, got)
+		}
+	}
+
+	d, ok := LookupDialect("clickhouse-test")
+	if !ok || d.Name != "clickhouse-test" {
+		t.Errorf("Expected registered dialect, got %+v (%v)", d, ok)
+	}
+}
+
+func TestRegisterColumnType(t *testing.T) {
+	original, _ := LookupDialect("postgres")
+	defer RegisterDialect(original)
+
+	RegisterColumnType("postgres", KindTime, FixedType("TIMESTAMP"))
+
+	if got := ColumnType("postgres", KindTime, Column{}); got != "TIMESTAMP" {
+		t.Errorf("Expected overridden type TIMESTAMP, got %q", got)
+	}
+	if got := ColumnType("postgres", KindInt64, Column{}); got != "BIGINT" {
+		t.Errorf("Expected other kinds to be kept, got %q", got)
+	}
+	if got := original.Types[KindTime](Column{}); got != "TIMESTAMP WITH TIME ZONE" {
+		t.Errorf("Expected looked up dialect to be unaffected, got %q", got)
+	}
+
+	RegisterColumnType("cockroachdb-test", KindString, SizedType("STRING(%s)", "STRING"))
+	d, ok := LookupDialect("cockroachdb-test")
+	if !ok || d.Name != "cockroachdb-test" || len(d.Types) != 1 {
+		t.Errorf("Expected new dialect with one mapping, got %+v (%v)", d, ok)
+	}
+	if got := ColumnType("cockroachdb-test", KindString, Column{Settings: map[string]string{"SIZE": "20"}}); got != "STRING(20)" {
+		t.Errorf("Expected STRING(20), got %q", got)
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if got := KindTime.String(); got != "NilTime" {
+		t.Errorf("Expected NilTime, got %q", got)
+	}
+	if got := Kind(99).String(); got != "Kind(99)" {
+		t.Errorf("Expected Kind(99), got %q", got)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	type wrapper struct{ NilTime }
+
+	tests := []struct {
+		typ      reflect.Type
+		expected Kind
+		ok       bool
+	}{
+		{reflect.TypeFor[NilString](), KindString, true},
+		{reflect.TypeFor[NilByte](), KindByte, true},
+		{reflect.TypeFor[NilEnum[testStatus]](), KindEnum, true},
+		{reflect.TypeFor[wrapper](), KindTime, true},
+		{reflect.TypeFor[string](), 0, false},
+		{reflect.TypeFor[struct{ Name string }](), 0, false},
+	}
+
+	for _, tt := range tests {
+		kind, ok := kindOf(tt.typ)
+		if kind != tt.expected || ok != tt.ok {
+			t.Errorf("%s: expected %v, %v, got %v, %v", tt.typ, tt.expected, tt.ok, kind, ok)
+		}
+	}
+
+	values := Enum(statusActive).RegisteredValues()
+	if len(values) != 2 || values[0] != "active" {
+		t.Errorf("Unexpected registered values %v", values)
+	}
+}
@@ -0,0 +1,148 @@
+package nihil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/mrrizkin/nihil/internal/jsonfield"
+)
+
+// Change describes one field whose value differs between two structs
+type Change struct {
+	Path string `json:"path"` // JSON path of the field, e.g. "address.city"
+	Old  any    `json:"old"`  // previous value, nil for null
+	New  any    `json:"new"`  // current value, nil for null
+}
+
+// String describes the change, e.g. `field "email" changed from null to "a@b.c"`
+func (c Change) String() string {
+	return "field " + strconv.Quote(c.Path) + " changed from " + renderValue(c.Old) + " to " + renderValue(c.New)
+}
+
+// renderValue formats a changed value as JSON, falling back to %v
+func renderValue(v any) string {
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Changes lists the fields that differ between two structs, in field order.
+// It encodes to JSON as an array of {"path", "old", "new"} objects, ready to
+// store in an audit table.
+type Changes []Change
+
+// Diff compares two structs of the same type field by field, returning the
+// changed fields with their JSON paths and their old and new values
+//
+// Nihil fields are compared null-aware: two nulls are equal, and a change to
+// or from null reports nil as the null side. Times, in nihil fields or not,
+// are compared with time.Time.Equal, so the same instant in another zone is
+// not a change. Nested structs are walked, with nil struct pointers treated
+// as empty structs; slices, maps and other values are compared whole. Old
+// or new may be a nil pointer, such as when auditing a creation or deletion.
+func Diff(old, new any) (Changes, error) {
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	if !ov.IsValid() || !nv.IsValid() || ov.Type() != nv.Type() {
+		return nil, fmt.Errorf("nihil: Diff expects two values of the same struct type, got %T and %T", old, new)
+	}
+	t := ov.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isNullable(t) {
+		return nil, fmt.Errorf("nihil: Diff expects structs, got %T", old)
+	}
+
+	var changes Changes
+	diffValue(ov, nv, "", &changes)
+	return changes, nil
+}
+
+// diffValue compares old and new, of the same type, appending their
+// differences to changes
+func diffValue(old, new reflect.Value, path string, changes *Changes) {
+	t := old.Type()
+	for t.Kind() == reflect.Pointer && (isNullable(t.Elem()) || isDiffStruct(t.Elem())) {
+		old, new, t = derefOrZero(old), derefOrZero(new), t.Elem()
+	}
+
+	switch {
+	case isNullable(t):
+		ov, ovalid := nullableValue(old)
+		nv, nvalid := nullableValue(new)
+		if ovalid != nvalid || ovalid && !equalValues(ov, nv) {
+			*changes = append(*changes, Change{Path: path, Old: nullOr(ov, ovalid), New: nullOr(nv, nvalid)})
+		}
+	case isDiffStruct(t):
+		for _, f := range jsonFields(t) {
+			diffValue(fieldOrZero(old, f), fieldOrZero(new, f), joinPath(path, f.Name), changes)
+		}
+	default:
+		ov, nv := plainValue(old), plainValue(new)
+		if !equalValues(ov, nv) {
+			*changes = append(*changes, Change{Path: path, Old: ov, New: nv})
+		}
+	}
+}
+
+// isDiffStruct reports whether Diff walks the fields of t rather than
+// comparing it whole
+func isDiffStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isNullable(t) && !t.Implements(reflect.TypeFor[json.Marshaler]()) &&
+		!reflect.PointerTo(t).Implements(reflect.TypeFor[json.Marshaler]())
+}
+
+// derefOrZero returns the value v points to, or the zero value for nil
+func derefOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
+
+// fieldOrZero returns the field f of v, or its zero value when it is
+// promoted through a nil embedded pointer
+func fieldOrZero(v reflect.Value, f jsonfield.Field) reflect.Value {
+	fv, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Zero(f.Type)
+	}
+	return fv
+}
+
+// plainValue returns the value of a non-nihil field, with nil pointers,
+// slices, maps and interfaces as nil and other pointers dereferenced
+func plainValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return plainValue(v.Elem())
+	case reflect.Slice, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return v.Interface()
+}
+
+// equalValues compares two field values, times by instant
+func equalValues(a, b any) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func nullOr(v any, valid bool) any {
+	if !valid {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,182 @@
+package nihil
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type diffAddress struct {
+	City NilString `json:"city"`
+}
+
+type diffAudit struct {
+	UpdatedBy string `json:"updated_by"`
+}
+
+type diffUser struct {
+	*diffAudit
+	Email     NilString    `json:"email"`
+	Age       NilInt32     `json:"age"`
+	LoginAt   NilTime      `json:"login_at"`
+	Nickname  *NilString   `json:"nickname"`
+	Status    string       `json:"status"`
+	Bio       *string      `json:"bio"`
+	CreatedAt time.Time    `json:"created_at"`
+	Tags      []string     `json:"tags"`
+	Address   diffAddress  `json:"address"`
+	Billing   *diffAddress `json:"billing"`
+	Internal  string       `json:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	instant := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	inWIB := instant.In(time.FixedZone("WIB", 7*3600))
+	bio := "gopher"
+
+	old := diffUser{
+		Email:     StringNil(),
+		Age:       Int32(30),
+		LoginAt:   Time(instant),
+		Status:    "active",
+		CreatedAt: instant,
+		Address:   diffAddress{City: String("Jakarta")},
+		Internal:  "a",
+	}
+	tests := []struct {
+		name     string
+		update   func(u *diffUser)
+		expected Changes
+	}{
+		{
+			name:     "no changes",
+			update:   func(u *diffUser) {},
+			expected: nil,
+		},
+		{
+			name:     "null to value",
+			update:   func(u *diffUser) { u.Email = String("abc") },
+			expected: Changes{{Path: "email", Old: nil, New: "abc"}},
+		},
+		{
+			name:     "value to null",
+			update:   func(u *diffUser) { u.Age = Int32Nil() },
+			expected: Changes{{Path: "age", Old: int32(30), New: nil}},
+		},
+		{
+			name:     "null values with different leftovers are equal",
+			update:   func(u *diffUser) { u.Email = NilString{String: "stale"} },
+			expected: nil,
+		},
+		{
+			name:     "same instant in another zone",
+			update:   func(u *diffUser) { u.LoginAt = Time(inWIB); u.CreatedAt = inWIB },
+			expected: nil,
+		},
+		{
+			name:     "time changed",
+			update:   func(u *diffUser) { u.LoginAt = Time(instant.Add(time.Hour)) },
+			expected: Changes{{Path: "login_at", Old: instant, New: instant.Add(time.Hour)}},
+		},
+		{
+			name: "pointers, plain fields and slices",
+			update: func(u *diffUser) {
+				nickname := String("al")
+				u.Nickname = &nickname
+				u.Bio = &bio
+				u.Status = "inactive"
+				u.Tags = []string{"a"}
+				u.Internal = "b"
+			},
+			expected: Changes{
+				{Path: "nickname", Old: nil, New: "al"},
+				{Path: "status", Old: "active", New: "inactive"},
+				{Path: "bio", Old: nil, New: "gopher"},
+				{Path: "tags", Old: nil, New: []string{"a"}},
+			},
+		},
+		{
+			name: "nested and embedded structs",
+			update: func(u *diffUser) {
+				u.diffAudit = &diffAudit{UpdatedBy: "admin"}
+				u.Address.City = String("Bandung")
+				u.Billing = &diffAddress{City: String("Bogor")}
+			},
+			expected: Changes{
+				{Path: "updated_by", Old: "", New: "admin"},
+				{Path: "address.city", Old: "Jakarta", New: "Bandung"},
+				{Path: "billing.city", Old: nil, New: "Bogor"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := old
+			tt.update(&updated)
+			changes, err := Diff(old, updated)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, changes)
+			}
+		})
+	}
+}
+
+func TestDiff_NilPointer(t *testing.T) {
+	created := &diffUser{Email: String("abc"), Status: "active"}
+	changes, err := Diff((*diffUser)(nil), created)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := Changes{
+		{Path: "email", Old: nil, New: "abc"},
+		{Path: "status", Old: "", New: "active"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected %v, got %v", expected, changes)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new any
+	}{
+		{"different types", diffUser{}, &diffUser{}},
+		{"untyped nil", nil, diffUser{}},
+		{"not a struct", "a", "b"},
+		{"nihil value", String("a"), String("b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Diff(tt.old, tt.new); err == nil || !strings.HasPrefix(err.Error(), "nihil: Diff expects") {
+				t.Errorf("Expected a Diff error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestChanges_JSON(t *testing.T) {
+	changes, err := Diff(diffUser{Age: Int32(30)}, diffUser{Email: String("abc"), Age: Int32(31)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	b, err := json.Marshal(changes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `[{"path":"email","old":null,"new":"abc"},{"path":"age","old":30,"new":31}]`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+
+	if s := changes[0].String(); s != `field "email" changed from null to "abc"` {
+		t.Errorf("Unexpected description: %s", s)
+	}
+}
@@ -0,0 +1,161 @@
+package nihil
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NilEnum is a nullable string-like enumeration whose values are restricted
+// to the set registered for E with RegisterEnum
+//
+// UnmarshalJSON, Scan and Value reject values outside the set, so invalid
+// values like "actve" never reach the database.
+type NilEnum[E ~string] struct {
+	Enum  E
+	Valid bool
+}
+
+// Enum creates a valid NilEnum with the given value
+func Enum[E ~string](e E) NilEnum[E] {
+	return NilEnum[E]{Valid: true, Enum: e}
+}
+
+// EnumNil creates an invalid (null) NilEnum
+func EnumNil[E ~string]() NilEnum[E] {
+	return NilEnum[E]{Valid: false}
+}
+
+var enumValues sync.Map // map[reflect.Type][]string
+
+// RegisterEnum sets the values allowed in a NilEnum[E], replacing any
+// earlier registration. Call it from an init function of the package
+// declaring E:
+//
+//	type Status string
+//
+//	const (
+//		StatusActive   Status = "active"
+//		StatusInactive Status = "inactive"
+//	)
+//
+//	func init() { nihil.RegisterEnum(StatusActive, StatusInactive) }
+func RegisterEnum[E ~string](values ...E) {
+	allowed := make([]string, len(values))
+	for i, v := range values {
+		allowed[i] = string(v)
+	}
+	enumValues.Store(reflect.TypeFor[E](), allowed)
+}
+
+// EnumValues returns the values registered for E, in registration order
+func EnumValues[E ~string]() []E {
+	allowed := registeredEnum[E]()
+	values := make([]E, len(allowed))
+	for i, v := range allowed {
+		values[i] = E(v)
+	}
+	return values
+}
+
+func registeredEnum[E ~string]() []string {
+	if allowed, ok := enumValues.Load(reflect.TypeFor[E]()); ok {
+		return allowed.([]string)
+	}
+	return nil
+}
+
+// RegisteredValues returns the values registered for E, in registration
+// order, for code that only has a NilEnum through reflection
+func (NilEnum[E]) RegisteredValues() []string {
+	return slices.Clone(registeredEnum[E]())
+}
+
+var registeredValuesType = reflect.TypeFor[interface{ RegisteredValues() []string }]()
+
+// IsKnown reports whether n is null or holds a registered value
+func (n NilEnum[E]) IsKnown() bool {
+	return !n.Valid || slices.Contains(registeredEnum[E](), string(n.Enum))
+}
+
+// enumTypeName names NilEnum[E] for errors without E's package path
+func enumTypeName[E ~string]() string {
+	return "NilEnum[" + reflect.TypeFor[E]().Name() + "]"
+}
+
+// enumExpected describes the values accepted for E
+func enumExpected[E ~string]() string {
+	allowed := registeredEnum[E]()
+	if len(allowed) == 0 {
+		return "a registered value (none registered)"
+	}
+	quoted := make([]string, len(allowed))
+	for i, v := range allowed {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "one of " + strings.Join(quoted, ", ")
+}
+
+// Interface implementations for nullableJSON
+func (n *NilEnum[E]) isValid() bool        { return n.Valid }
+func (n *NilEnum[E]) getValue() E          { return n.Enum }
+func (n *NilEnum[E]) setValid(v bool)      { n.Valid = v }
+func (n *NilEnum[E]) setValue(value E)     { n.Enum = value }
+func (n *NilEnum[E]) scan(value any) error { return scanNullable(n, value, convertEnum[E]) }
+func (n *NilEnum[E]) driverValue() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if !n.IsKnown() {
+		return nil, &EnumError{Type: enumTypeName[E](), Value: string(n.Enum), Expected: enumExpected[E]()}
+	}
+	return string(n.Enum), nil
+}
+
+func (n *NilEnum[E]) Scan(value any) error        { return n.scan(value) }
+func (n NilEnum[E]) Value() (driver.Value, error) { return n.driverValue() }
+
+func (n NilEnum[E]) MarshalJSON() ([]byte, error) { return marshalNullableJSON((*NilEnum[E])(&n)) }
+func (n *NilEnum[E]) UnmarshalJSON(b []byte) error {
+	var decoded NilEnum[E]
+	err := unmarshalNullableJSON(&decoded, b)
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		if decoded.IsKnown() {
+			*n = decoded
+			return nil
+		}
+		de = newDecodeError[E](n, b, nil)
+	}
+	de.Type = enumTypeName[E]()
+	de.Expected = enumExpected[E]() + " or null"
+	return de
+}
+
+// convertEnum accepts text holding one of the registered values of E
+func convertEnum[E ~string](value any) (E, error) {
+	s, ok := textOf(value)
+	if !ok {
+		return "", scanError(enumTypeName[E](), value, "want text")
+	}
+	if !slices.Contains(registeredEnum[E](), s) {
+		return "", scanError(enumTypeName[E](), value, "want "+enumExpected[E]())
+	}
+	return E(s), nil
+}
+
+// EnumError is returned by NilEnum.Value for a value outside the registered set
+type EnumError struct {
+	Type     string // e.g. "NilEnum[Status]"
+	Value    string // the rejected value
+	Expected string // description of the registered values
+}
+
+func (e *EnumError) Error() string {
+	return "nihil: " + strconv.Quote(e.Value) + " is not a valid " + e.Type + ": expected " + e.Expected
+}
@@ -0,0 +1,116 @@
+package nihil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	statusActive   testStatus = "active"
+	statusInactive testStatus = "inactive"
+)
+
+type testUnregistered string
+
+func init() {
+	RegisterEnum(statusActive, statusInactive)
+}
+
+func TestNilEnum_Constructor(t *testing.T) {
+	valid := Enum(statusActive)
+	if !valid.Valid || valid.Enum != statusActive {
+		t.Errorf("Expected valid active enum, got %+v", valid)
+	}
+
+	nilEnum := EnumNil[testStatus]()
+	if nilEnum.Valid {
+		t.Error("Expected nil enum to be invalid")
+	}
+
+	values := EnumValues[testStatus]()
+	if len(values) != 2 || values[0] != statusActive || values[1] != statusInactive {
+		t.Errorf("Unexpected registered values: %v", values)
+	}
+}
+
+func TestNilEnum_JSON(t *testing.T) {
+	data, err := json.Marshal(Enum(statusInactive))
+	if err != nil || string(data) != `"inactive"` {
+		t.Errorf("Expected \"inactive\", got %s (%v)", data, err)
+	}
+	data, err = json.Marshal(EnumNil[testStatus]())
+	if err != nil || string(data) != "null" {
+		t.Errorf("Expected null, got %s (%v)", data, err)
+	}
+
+	var result NilEnum[testStatus]
+	if err := json.Unmarshal([]byte(`"active"`), &result); err != nil || !result.Valid || result.Enum != statusActive {
+		t.Errorf("Expected active, got %+v (%v)", result, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &result); err != nil || result.Valid {
+		t.Errorf("Expected null, got %+v (%v)", result, err)
+	}
+
+	result = Enum(statusActive)
+	err = json.Unmarshal([]byte(`"actve"`), &result)
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %T: %v", err, err)
+	}
+	if de.Type != "NilEnum[testStatus]" {
+		t.Errorf("Unexpected type %s", de.Type)
+	}
+	if de.Expected != `one of "active", "inactive" or null` {
+		t.Errorf("Unexpected expectation %s", de.Expected)
+	}
+	if result.Enum != statusActive {
+		t.Error("Expected rejected input to leave the value untouched")
+	}
+
+	if err := json.Unmarshal([]byte(`5`), &result); !errors.As(err, &de) {
+		t.Errorf("Expected *DecodeError for a number, got %v", err)
+	}
+}
+
+func TestNilEnum_Database(t *testing.T) {
+	var result NilEnum[testStatus]
+	if err := result.Scan([]byte("inactive")); err != nil || result.Enum != statusInactive {
+		t.Errorf("Expected inactive, got %+v (%v)", result, err)
+	}
+	if err := result.Scan(nil); err != nil || result.Valid {
+		t.Errorf("Expected null, got %+v (%v)", result, err)
+	}
+
+	var se *ScanError
+	if err := result.Scan("actve"); !errors.As(err, &se) {
+		t.Errorf("Expected *ScanError, got %v", err)
+	}
+
+	val, err := Enum(statusActive).Value()
+	if err != nil || val != "active" {
+		t.Errorf("Expected active, got %v (%v)", val, err)
+	}
+
+	var ee *EnumError
+	if _, err := Enum(testStatus("actve")).Value(); !errors.As(err, &ee) {
+		t.Errorf("Expected *EnumError, got %v", err)
+	}
+	if _, err := Enum(testUnregistered("x")).Value(); !errors.As(err, &ee) {
+		t.Errorf("Expected *EnumError for unregistered type, got %v", err)
+	}
+}
+
+func TestNilEnum_Validate(t *testing.T) {
+	type form struct {
+		Status NilEnum[testStatus] `json:"status" nihil:"notnull,oneof=active"`
+	}
+
+	err := Validate(form{Status: Enum(statusInactive)})
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Rule != "oneof" {
+		t.Errorf("Expected oneof violation, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package nihil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSnippet bounds how much of the offending input an error carries
+const maxSnippet = 64
+
+// DecodeError is returned when JSON input cannot be decoded into a nihil type
+//
+// Path is the dotted JSON path of the field ("address.zip", "items[2].price").
+// It is empty when the value was decoded on its own or through plain
+// json.Unmarshal, which does not report field context for custom
+// unmarshalers; decode with Unmarshal to have it filled in.
+type DecodeError struct {
+	Type     string // nihil type being decoded, e.g. "NilInt32"
+	Expected string // human readable description, e.g. "integer or null"
+	Input    string // the offending JSON, truncated
+	Path     string // JSON path of the field, when known
+	Err      error  // underlying encoding/json error
+}
+
+func (e *DecodeError) Error() string {
+	msg := "nihil: "
+	if e.Path != "" {
+		msg += "field " + strconv.Quote(e.Path) + ": "
+	}
+	return msg + "cannot decode " + e.Input + " into " + e.Type + ": expected " + e.Expected
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// ScanError is returned when a database value cannot be scanned into a nihil type
+//
+// Column is empty when Scan is called directly by database/sql; helpers
+// that know the column being scanned fill it in.
+type ScanError struct {
+	Type   string       // type being scanned into, e.g. "NilInt32"
+	Source reflect.Type // Go type of the driver value
+	Value  any          // the driver value itself, nil for NULL
+	Column string       // column name, when known
+	Reason string       // what was expected instead
+}
+
+func (e *ScanError) Error() string {
+	msg := "nihil: "
+	if e.Column != "" {
+		msg += "column " + strconv.Quote(e.Column) + ": "
+	}
+	if e.Value == nil {
+		return fmt.Sprintf("%scannot scan NULL into %s: %s", msg, e.Type, e.Reason)
+	}
+	return fmt.Sprintf("%scannot scan %v (%s) into %s: %s", msg, e.Source, describeValue(e.Value), e.Type, e.Reason)
+}
+
+// newDecodeError builds the DecodeError for input that failed to decode into T
+func newDecodeError[T any](target any, input []byte, err error) *DecodeError {
+	snippet := string(input)
+	if len(snippet) > maxSnippet {
+		cut := maxSnippet
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut-- // don't split a multi-byte character
+		}
+		snippet = snippet[:cut] + "..."
+	}
+	return &DecodeError{
+		Type:     reflect.TypeOf(target).Elem().Name(),
+		Expected: expectedJSON(reflect.TypeFor[T]()),
+		Input:    snippet,
+		Err:      err,
+	}
+}
+
+// expectedJSON describes the JSON accepted for a value of type t
+func expectedJSON(t reflect.Type) string {
+	if t == reflect.TypeFor[time.Time]() {
+		return "RFC 3339 timestamp or null"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean or null"
+	case reflect.Uint8:
+		return "integer between 0 and 255 or null"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer or null"
+	case reflect.Float32, reflect.Float64:
+		return "number or null"
+	case reflect.String:
+		return "string or null"
+	}
+	return t.String() + " or null"
+}
+
+// Unmarshal is json.Unmarshal that fills in DecodeError.Path
+//
+// encoding/json passes custom unmarshaler errors through without field
+// context, so when decoding fails on a nihil field Unmarshal walks the
+// input alongside the target type to find the path of the failing field.
+func Unmarshal(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+
+	var de *DecodeError
+	if err == nil || !errors.As(err, &de) || de.Path != "" {
+		return err
+	}
+	if located := locateDecodeError(reflect.TypeOf(v), data, ""); located != nil {
+		return located
+	}
+	return err
+}
+
+// locateDecodeError finds the first value in data that fails to decode into
+// its nihil type, returning its DecodeError with Path set
+func locateDecodeError(t reflect.Type, data []byte, path string) *DecodeError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		err := json.Unmarshal(data, reflect.New(t).Interface())
+		var de *DecodeError
+		if errors.As(err, &de) {
+			located := *de
+			if located.Path == "" {
+				located.Path = path
+			}
+			return &located
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		for _, member := range objectMembers(data) {
+			if f, ok := lookupJSONField(fields, member.key); ok {
+				if de := locateDecodeError(f.Type, member.value, joinPath(path, f.Name)); de != nil {
+					return de
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var array []json.RawMessage
+		if json.Unmarshal(data, &array) != nil {
+			return nil
+		}
+		for i, raw := range array {
+			if de := locateDecodeError(t.Elem(), raw, path+"["+strconv.Itoa(i)+"]"); de != nil {
+				return de
+			}
+		}
+	case reflect.Map:
+		for _, member := range objectMembers(data) {
+			if de := locateDecodeError(t.Elem(), member.value, joinPath(path, member.key)); de != nil {
+				return de
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package nihil
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDecodeError_FromJSONUnmarshal(t *testing.T) {
+	var result NilInt32
+	err := json.Unmarshal([]byte(`"abc"`), &result)
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %T: %v", err, err)
+	}
+	if de.Type != "NilInt32" {
+		t.Errorf("Expected type NilInt32, got %s", de.Type)
+	}
+	if de.Expected != "integer or null" {
+		t.Errorf("Expected 'integer or null', got %s", de.Expected)
+	}
+	if de.Input != `"abc"` {
+		t.Errorf("Expected input snippet %q, got %q", `"abc"`, de.Input)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Error("Expected DecodeError to unwrap to the encoding/json error")
+	}
+}
+
+func TestDecodeError_SnippetKeepsUTF8(t *testing.T) {
+	input := `"` + strings.Repeat("é", 40) + `"` // 82 bytes, "é" at odd offsets
+	var result NilInt32
+	err := json.Unmarshal([]byte(input), &result)
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %T: %v", err, err)
+	}
+	if !utf8.ValidString(de.Input) {
+		t.Errorf("Expected valid UTF-8 snippet, got %q", de.Input)
+	}
+	if !strings.HasSuffix(de.Input, "...") || len(de.Input) > maxSnippet+len("...") {
+		t.Errorf("Expected truncated snippet, got %q", de.Input)
+	}
+}
+
+func TestUnmarshal_FillsPath(t *testing.T) {
+	type Address struct {
+		Zip NilInt32 `json:"zip"`
+	}
+	type Item struct {
+		Price NilFloat64 `json:"price"`
+	}
+	type Request struct {
+		Name    NilString `json:"name"`
+		Age     NilInt32  `json:"age"`
+		Address Address   `json:"address"`
+		Items   []Item    `json:"items"`
+	}
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedPath string
+		expectedType string
+	}{
+		{"top level", `{"name":"x","age":"old"}`, "age", "NilInt32"},
+		{"case insensitive key", `{"AGE":true}`, "age", "NilInt32"},
+		{"nested struct", `{"address":{"zip":"12a"}}`, "address.zip", "NilInt32"},
+		{"slice element", `{"items":[{"price":1},{"price":"free"}]}`, "items[1].price", "NilFloat64"},
+		{"string", `{"name":42}`, "name", "NilString"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req Request
+			err := Unmarshal([]byte(tt.input), &req)
+
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("Expected *DecodeError, got %T: %v", err, err)
+			}
+			if de.Path != tt.expectedPath {
+				t.Errorf("Expected path %s, got %s", tt.expectedPath, de.Path)
+			}
+			if de.Type != tt.expectedType {
+				t.Errorf("Expected type %s, got %s", tt.expectedType, de.Type)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_PassesThroughOtherErrors(t *testing.T) {
+	var req struct {
+		Age NilInt32 `json:"age"`
+	}
+	if err := Unmarshal([]byte(`{"age":1}`), &req); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !req.Age.Valid || req.Age.Int32 != 1 {
+		t.Error("Age field mismatch")
+	}
+
+	err := Unmarshal([]byte(`{"age":`), &req)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected *json.SyntaxError, got %T: %v", err, err)
+	}
+}
+
+func TestScanError(t *testing.T) {
+	var result NilInt32
+	err := result.Scan([]byte("abc"))
+
+	var se *ScanError
+	if !errors.As(err, &se) {
+		t.Fatalf("Expected *ScanError, got %T: %v", err, err)
+	}
+	if se.Type != "NilInt32" {
+		t.Errorf("Expected type NilInt32, got %s", se.Type)
+	}
+	if se.Source != reflect.TypeFor[[]byte]() {
+		t.Errorf("Expected source []uint8, got %v", se.Source)
+	}
+
+	se.Column = "age"
+	expected := `nihil: column "age": cannot scan []uint8 ("abc") into NilInt32: want an integer, or integer text`
+	if se.Error() != expected {
+		t.Errorf("Expected %s, got %s", expected, se.Error())
+	}
+}
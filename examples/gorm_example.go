@@ -6,24 +6,24 @@ import (
 	"log"
 	"time"
 
-	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/nihilgorm"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 // User model with nihil types
 type User struct {
-	ID          uint             `gorm:"primarykey"           json:"id"`
-	Name        nihil.NilString  `gorm:"size:100;not null"    json:"name"`
-	Email       nihil.NilString  `gorm:"size:255;uniqueIndex" json:"email"`
-	Age         nihil.NilInt32   `gorm:"check:age > 0"        json:"age"`
-	Score       nihil.NilFloat64 `gorm:"precision:2"          json:"score"`
-	IsActive    nihil.NilBool    `gorm:"default:true"         json:"is_active"`
-	Level       nihil.NilByte    `gorm:""                     json:"level"`
-	Points      nihil.NilInt64   `gorm:"default:0"            json:"points"`
-	LastLoginAt nihil.NilTime    `gorm:"precision:6"          json:"last_login_at"`
-	CreatedAt   time.Time        `gorm:"autoCreateTime"       json:"created_at"`
-	UpdatedAt   time.Time        `gorm:"autoUpdateTime"       json:"updated_at"`
+	ID          uint                 `gorm:"primarykey"           json:"id"`
+	Name        nihilgorm.NilString  `gorm:"size:100;not null"    json:"name"`
+	Email       nihilgorm.NilString  `gorm:"size:255;uniqueIndex" json:"email"`
+	Age         nihilgorm.NilInt32   `gorm:"check:age > 0"        json:"age"`
+	Score       nihilgorm.NilFloat64 `gorm:"precision:2"          json:"score"`
+	IsActive    nihilgorm.NilBool    `gorm:"default:true"         json:"is_active"`
+	Level       nihilgorm.NilByte    `gorm:""                     json:"level"`
+	Points      nihilgorm.NilInt64   `gorm:"default:0"            json:"points"`
+	LastLoginAt nihilgorm.NilTime    `gorm:"precision:6"          json:"last_login_at"`
+	CreatedAt   time.Time            `gorm:"autoCreateTime"       json:"created_at"`
+	UpdatedAt   time.Time            `gorm:"autoUpdateTime"       json:"updated_at"`
 }
 
 // BeforeCreate hook example
@@ -52,33 +52,33 @@ func main() {
 	// Create users with different null combinations
 	users := []User{
 		{
-			Name:        nihil.String("Alice Johnson"),
-			Email:       nihil.String("alice@example.com"),
-			Age:         nihil.Int32(28),
-			Score:       nihil.Float64(95.5),
-			IsActive:    nihil.Bool(true),
-			Level:       nihil.Byte(5),
-			Points:      nihil.Int64(1500),
-			LastLoginAt: nihil.Time(time.Now()),
+			Name:        nihilgorm.String("Alice Johnson"),
+			Email:       nihilgorm.String("alice@example.com"),
+			Age:         nihilgorm.Int32(28),
+			Score:       nihilgorm.Float64(95.5),
+			IsActive:    nihilgorm.Bool(true),
+			Level:       nihilgorm.Byte(5),
+			Points:      nihilgorm.Int64(1500),
+			LastLoginAt: nihilgorm.Time(time.Now()),
 		},
 		{
-			Name:        nihil.String("Bob Smith"),
-			Email:       nihil.StringNil(), // null email
-			Age:         nihil.Int32Nil(),  // null age
-			Score:       nihil.Float64(88.0),
-			IsActive:    nihil.BoolNil(), // null active status
-			Level:       nihil.ByteNil(), // null level
-			Points:      nihil.Int64(500),
-			LastLoginAt: nihil.TimeNil(), // never logged in
+			Name:        nihilgorm.String("Bob Smith"),
+			Email:       nihilgorm.StringNil(), // null email
+			Age:         nihilgorm.Int32Nil(),  // null age
+			Score:       nihilgorm.Float64(88.0),
+			IsActive:    nihilgorm.BoolNil(), // null active status
+			Level:       nihilgorm.ByteNil(), // null level
+			Points:      nihilgorm.Int64(500),
+			LastLoginAt: nihilgorm.TimeNil(), // never logged in
 		},
 		{
-			Name:     nihil.String("Charlie Brown"),
-			Email:    nihil.String("charlie@example.com"),
-			Age:      nihil.Int32(35),
-			Score:    nihil.Float64Nil(), // no score yet
-			IsActive: nihil.Bool(false),
-			Level:    nihil.Byte(1),
-			Points:   nihil.Int64Nil(), // no points
+			Name:     nihilgorm.String("Charlie Brown"),
+			Email:    nihilgorm.String("charlie@example.com"),
+			Age:      nihilgorm.Int32(35),
+			Score:    nihilgorm.Float64Nil(), // no score yet
+			IsActive: nihilgorm.Bool(false),
+			Level:    nihilgorm.Byte(1),
+			Points:   nihilgorm.Int64Nil(), // no points
 		},
 	}
 
@@ -124,7 +124,7 @@ func main() {
 
 	// Update with null values
 	fmt.Println("\n=== Updating user to have null score ===")
-	db.Model(&User{}).Where("id = ?", 1).Update("score", nihil.Float64Nil())
+	db.Model(&User{}).Where("id = ?", 1).Update("score", nihilgorm.Float64Nil())
 
 	// Raw SQL with null handling
 	fmt.Println("\n=== Raw SQL Query ===")
@@ -0,0 +1,101 @@
+package nihil
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mrrizkin/nihil/internal/jsonfield"
+)
+
+var jsonFieldsCache sync.Map // map[reflect.Type][]jsonfield.Field
+
+// jsonFields returns the JSON-visible fields of struct type t in index
+// order, following the encoding/json rules, with nihil types never treated
+// as embedded structs
+func jsonFields(t reflect.Type) []jsonfield.Field {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.([]jsonfield.Field)
+	}
+	cached, _ := jsonFieldsCache.LoadOrStore(t, jsonfield.Fields(t, isNullable))
+	return cached.([]jsonfield.Field)
+}
+
+// lookupJSONField finds the field for a JSON key, preferring an exact match
+// and falling back to a case-insensitive one like encoding/json does
+func lookupJSONField(fields []jsonfield.Field, key string) (jsonfield.Field, bool) {
+	for _, f := range fields {
+		if f.Name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, key) {
+			return f, true
+		}
+	}
+	return jsonfield.Field{}, false
+}
+
+// joinPath appends a JSON object key to a field path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// isNullable reports whether t is one of the nihil nullable types
+func isNullable(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(nullableType)
+}
+
+// nullableValue returns the wrapped value of a nihil type and whether it is
+// valid. All nihil types share the sql.Null* layout of the value followed
+// by Valid; types embedding a nihil type are unwrapped first.
+func nullableValue(rv reflect.Value) (any, bool) {
+	for rv.Kind() == reflect.Struct && rv.NumField() > 0 && rv.Type().Field(0).Anonymous && isNullable(rv.Field(0).Type()) {
+		rv = rv.Field(0)
+	}
+	return rv.Field(0).Interface(), rv.FieldByName("Valid").Bool()
+}
+
+// nullable is the non-generic part of nullableJSON, used for reflection
+type nullable interface {
+	isValid() bool
+	setValid(bool)
+	scan(value any) error
+}
+
+var nullableType = reflect.TypeFor[nullable]()
+
+// objectMember is one key/value pair of a JSON object
+type objectMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// objectMembers splits a JSON object into its members in document order,
+// returning nil when data is not an object
+func objectMembers(data []byte) []objectMember {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+
+	var members []objectMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil
+		}
+		members = append(members, objectMember{key: tok.(string), value: value})
+	}
+	return members
+}
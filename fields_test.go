@@ -0,0 +1,111 @@
+package nihil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mrrizkin/nihil/internal/jsonfield"
+)
+
+type fieldsBase struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type fieldsUser struct {
+	*fieldsBase
+	Name     string    `json:"full_name"`
+	Email    NilString `json:"email"`
+	Password string    `json:"-"`
+	Nickname NilString
+	internal int
+}
+
+func TestJSONFields(t *testing.T) {
+	fields := jsonFields(reflect.TypeFor[fieldsUser]())
+
+	expected := []jsonfield.Field{
+		{Name: "id", Index: []int{0, 0}, Type: reflect.TypeFor[int64](), Tag: `json:"id"`},
+		{Name: "name", Index: []int{0, 1}, Type: reflect.TypeFor[string](), Tag: `json:"name"`},
+		{Name: "full_name", Index: []int{1}, Type: reflect.TypeFor[string](), Tag: `json:"full_name"`},
+		{Name: "email", Index: []int{2}, Type: reflect.TypeFor[NilString](), Tag: `json:"email"`},
+		{Name: "Nickname", Index: []int{4}, Type: reflect.TypeFor[NilString]()},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, fields)
+	}
+}
+
+type fieldsA struct {
+	Shared NilString `json:"shared"`
+	Plain  NilString
+	Only   NilString `json:"only_a"`
+}
+
+type fieldsB struct {
+	Shared NilString `json:"shared"`
+	Plain  NilString
+	Tagged NilString `json:"Plain"`
+}
+
+type FieldsA fieldsA
+
+type FieldsB fieldsB
+
+type FieldsDeep struct{ FieldsA }
+
+// embedding returns a struct type embedding the given types, built at run
+// time since vet rejects json tags repeated across embedded structs
+func embedding(types ...any) reflect.Type {
+	var fields []reflect.StructField
+	for _, v := range types {
+		switch v := v.(type) {
+		case reflect.StructField:
+			fields = append(fields, v)
+		default:
+			t := reflect.TypeOf(v)
+			fields = append(fields, reflect.StructField{Name: t.Name(), Type: t, Anonymous: true})
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+func TestJSONFields_Dominance(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    reflect.Type
+		expected []string
+	}{
+		// shared ties at equal depth and is dropped; Plain is won by the
+		// tagged field of fieldsB; the outer only_a beats the embedded one
+		{"ambiguous", embedding(FieldsA{}, FieldsB{}, reflect.StructField{
+			Name: "Outer", Type: reflect.TypeFor[NilString](), Tag: `json:"only_a"`,
+		}), []string{"Plain", "only_a"}},
+		// FieldsB's fields are shallower than those of the FieldsA inside
+		{"depth", embedding(FieldsDeep{}, FieldsB{}), []string{"only_a", "shared", "Plain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, f := range jsonFields(tt.model) {
+				names = append(names, f.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+
+			// encoding/json writes the same keys
+			data, err := json.Marshal(reflect.New(tt.model).Elem().Interface())
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var object map[string]any
+			json.Unmarshal(data, &object)
+			if len(object) != len(tt.expected) {
+				t.Errorf("Expected json.Marshal to write %v, got %s", tt.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package nihil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// GraphQL scalar marshaling, compatible with gqlgen's Marshaler and
+// Unmarshaler interfaces without depending on gqlgen
+//
+// Bind a nihil type to a nullable scalar in gqlgen.yml; leave the field
+// without "!" in the schema, since null is a value of every nihil type:
+//
+//	models:
+//	  NullableString:
+//	    model: github.com/mrrizkin/nihil.NilString
+//
+//	type User {
+//	  nickname: NullableString
+//	}
+//
+// Null values are written as null, and null or missing input is decoded
+// as null. Input follows GraphQL's input coercion, with numbers as int,
+// int64, float64 or json.Number, the way gqlgen passes them: integer types
+// accept integral numbers and, like gqlgen's UnmarshalInt, their decimal
+// text, with range checks; NilFloat64 any number or its text; NilBool only
+// true or false; NilString and NilEnum only strings; and NilTime an RFC
+// 3339 string or a time.Time.
+
+// marshalNullableGQL writes the GraphQL form of n, which is its JSON form.
+// MarshalGQL cannot fail, so values JSON rejects, such as NaN, are null.
+func marshalNullableGQL[T any](n nullableJSON[T], w io.Writer) {
+	b, err := marshalNullableJSON(n)
+	if err != nil {
+		b = []byte("null")
+	}
+	w.Write(b)
+}
+
+// unmarshalNullableGQL sets n from a GraphQL input value, leaving n
+// untouched when the value cannot be converted
+func unmarshalNullableGQL[T any](n nullableJSON[T], v any, convert func(any) (T, error)) error {
+	if v == nil {
+		var zero T
+		n.setValue(zero)
+		n.setValid(false)
+		return nil
+	}
+	value, err := convert(v)
+	if err != nil {
+		return newDecodeError[T](n, []byte(describeValue(v)), err)
+	}
+	n.setValue(value)
+	n.setValid(true)
+	return nil
+}
+
+// gqlInt accepts the integral numbers of a GraphQL Int, and like gqlgen's
+// UnmarshalInt their decimal text, but not booleans
+func gqlInt[T any](convert func(any) (T, error)) func(any) (T, error) {
+	return func(v any) (T, error) {
+		switch value := v.(type) {
+		case json.Number, string:
+			text := fmt.Sprint(value)
+			i, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				var zero T
+				return zero, fmt.Errorf("want an integral number, got %q", text)
+			}
+			v = i
+		case bool:
+			var zero T
+			return zero, fmt.Errorf("want an integral number, got %T", v)
+		}
+		return convert(v)
+	}
+}
+
+// gqlFloat accepts the numbers of a GraphQL Float, and like gqlgen's
+// UnmarshalFloat their text, but not booleans
+func gqlFloat(v any) (float64, error) {
+	switch value := v.(type) {
+	case json.Number, string:
+		text := fmt.Sprint(value)
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("want a number, got %q", text)
+		}
+		v = f
+	case bool:
+		return 0, fmt.Errorf("want a number, got %T", v)
+	}
+	return convertFloat64(v)
+}
+
+// gqlBool accepts only the true and false of a GraphQL Boolean
+func gqlBool(v any) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("want true or false, got %T", v)
+	}
+	return b, nil
+}
+
+// gqlString accepts only strings, as GraphQL String and enum inputs do
+func gqlString(v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("want a string, got %T", v)
+	}
+	return s, nil
+}
+
+// gqlTime accepts time.Time values and RFC 3339 strings
+func gqlTime(v any) (time.Time, error) {
+	switch value := v.(type) {
+	case time.Time:
+		return value, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return time.Time{}, fmt.Errorf("want an RFC 3339 string, got %T", v)
+}
+
+func (n NilByte) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilByte)(&n), w) }
+func (n *NilByte) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlInt(convertByte)) }
+
+func (n NilBool) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilBool)(&n), w) }
+func (n *NilBool) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlBool) }
+
+func (n NilFloat64) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilFloat64)(&n), w) }
+func (n *NilFloat64) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlFloat) }
+
+func (n NilInt16) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilInt16)(&n), w) }
+func (n *NilInt16) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlInt(convertInt16)) }
+
+func (n NilInt32) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilInt32)(&n), w) }
+func (n *NilInt32) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlInt(convertInt32)) }
+
+func (n NilInt64) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilInt64)(&n), w) }
+func (n *NilInt64) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlInt(convertInt64)) }
+
+func (n NilString) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilString)(&n), w) }
+func (n *NilString) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlString) }
+
+func (n NilTime) MarshalGQL(w io.Writer)    { marshalNullableGQL((*NilTime)(&n), w) }
+func (n *NilTime) UnmarshalGQL(v any) error { return unmarshalNullableGQL(n, v, gqlTime) }
+
+func (n NilEnum[E]) MarshalGQL(w io.Writer) { marshalNullableGQL((*NilEnum[E])(&n), w) }
+func (n *NilEnum[E]) UnmarshalGQL(v any) error {
+	err := unmarshalNullableGQL(n, v, func(v any) (E, error) {
+		s, err := gqlString(v)
+		if err != nil {
+			return "", err
+		}
+		return convertEnum[E](s)
+	})
+	if de, ok := err.(*DecodeError); ok {
+		de.Type = enumTypeName[E]()
+		de.Expected = enumExpected[E]() + " or null"
+	}
+	return err
+}
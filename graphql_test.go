@@ -0,0 +1,150 @@
+package nihil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func marshalGQL(m interface{ MarshalGQL(w io.Writer) }) string {
+	var buf bytes.Buffer
+	m.MarshalGQL(&buf)
+	return buf.String()
+}
+
+func TestMarshalGQL(t *testing.T) {
+	testTime := time.Date(2023, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    interface{ MarshalGQL(w io.Writer) }
+		expected string
+	}{
+		{"byte", Byte(7), "7"},
+		{"bool", Bool(true), "true"},
+		{"float64", Float64(1.5), "1.5"},
+		{"float64 NaN", Float64(math.NaN()), "null"},
+		{"int16", Int16(-3), "-3"},
+		{"int32", Int32(42), "42"},
+		{"int64", Int64(1 << 40), "1099511627776"},
+		{"string", String(`say "hi"`), `"say \"hi\""`},
+		{"time", Time(testTime), `"2023-10-15T14:30:00Z"`},
+		{"enum", Enum(statusActive), `"active"`},
+		{"null string", StringNil(), "null"},
+		{"null time", TimeNil(), "null"},
+		{"null enum", EnumNil[testStatus](), "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshalGQL(tt.input); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	testTime := time.Date(2023, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		target   interface{ UnmarshalGQL(any) error }
+		input    any
+		expected any
+	}{
+		{"int32 from int", new(NilInt32), 42, Int32(42)},
+		{"int32 from int64", new(NilInt32), int64(42), Int32(42)},
+		{"int32 from float64", new(NilInt32), float64(42), Int32(42)},
+		{"int32 from json.Number", new(NilInt32), json.Number("42"), Int32(42)},
+		{"int64 from json.Number", new(NilInt64), json.Number("9007199254740993"), Int64(9007199254740993)},
+		{"int16 null", &NilInt16{Valid: true, Int16: 5}, nil, Int16Nil()},
+		{"byte", new(NilByte), 200, Byte(200)},
+		{"float64 from int", new(NilFloat64), 3, Float64(3)},
+		{"float64 from json.Number", new(NilFloat64), json.Number("2.5"), Float64(2.5)},
+		{"int32 from text", new(NilInt32), "42", Int32(42)},
+		{"int64 from text", new(NilInt64), "-9007199254740993", Int64(-9007199254740993)},
+		{"byte from text", new(NilByte), "255", Byte(255)},
+		{"float64 from text", new(NilFloat64), "1.5", Float64(1.5)},
+		{"float64 from integer text", new(NilFloat64), "-3", Float64(-3)},
+		{"bool", new(NilBool), true, Bool(true)},
+		{"bool false", &NilBool{Valid: true, Bool: true}, false, Bool(false)},
+		{"string", new(NilString), "hello", String("hello")},
+		{"string null", &NilString{Valid: true, String: "x"}, nil, StringNil()},
+		{"time from string", new(NilTime), "2023-10-15T14:30:00Z", Time(testTime)},
+		{"time", new(NilTime), testTime, Time(testTime)},
+		{"enum", new(NilEnum[testStatus]), "inactive", Enum(statusInactive)},
+		{"enum null", new(NilEnum[testStatus]), nil, EnumNil[testStatus]()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.target.UnmarshalGQL(tt.input); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := reflect.ValueOf(tt.target).Elem().Interface(); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalGQL_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{ UnmarshalGQL(any) error }
+		input    any
+		typeName string
+	}{
+		{"int32 fraction", new(NilInt32), 1.5, "NilInt32"},
+		{"int16 range", new(NilInt16), json.Number("70000"), "NilInt16"},
+		{"byte negative", new(NilByte), -1, "NilByte"},
+		{"int32 text", new(NilInt32), "forty-two", "NilInt32"},
+		{"int32 fraction text", new(NilInt32), "1.5", "NilInt32"},
+		{"int16 range text", new(NilInt16), "70000", "NilInt16"},
+		{"byte range text", new(NilByte), "256", "NilByte"},
+		{"byte negative text", new(NilByte), "-1", "NilByte"},
+		{"int64 range text", new(NilInt64), "9223372036854775808", "NilInt64"},
+		{"int32 bool", new(NilInt32), true, "NilInt32"},
+		{"int64 fraction", new(NilInt64), json.Number("1.5"), "NilInt64"},
+		{"int64 exponent", new(NilInt64), json.Number("1e3"), "NilInt64"},
+		{"float64 text", new(NilFloat64), "one and a half", "NilFloat64"},
+		{"float64 range text", new(NilFloat64), "1e400", "NilFloat64"},
+		{"float64 bool", new(NilFloat64), true, "NilFloat64"},
+		{"bool integer", new(NilBool), 1, "NilBool"},
+		{"bool text", new(NilBool), "true", "NilBool"},
+		{"string number", new(NilString), json.Number("12"), "NilString"},
+		{"string bool", new(NilString), false, "NilString"},
+		{"time unix", new(NilTime), int64(1697380200), "NilTime"},
+		{"time sqlite text", new(NilTime), "2023-10-15 14:30:00", "NilTime"},
+		{"enum number", new(NilEnum[testStatus]), 1, "NilEnum[testStatus]"},
+		{"bool text", new(NilBool), "maybe", "NilBool"},
+		{"string object", new(NilString), map[string]any{}, "NilString"},
+		{"time text", new(NilTime), "yesterday", "NilTime"},
+		{"enum unknown", new(NilEnum[testStatus]), "actve", "NilEnum[testStatus]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.UnmarshalGQL(tt.input)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("Expected *DecodeError, got %T: %v", err, err)
+			}
+			if de.Type != tt.typeName {
+				t.Errorf("Expected type %s, got %s", tt.typeName, de.Type)
+			}
+		})
+	}
+
+	// Rejected input leaves the value untouched
+	n := Int32(7)
+	if err := n.UnmarshalGQL("seven"); err == nil || n != Int32(7) {
+		t.Errorf("Expected error and unchanged value, got %+v (%v)", n, err)
+	}
+}
@@ -35,7 +35,7 @@ func unmarshalNullableJSON[T any](n nullableJSON[T], b []byte) error {
 
 	var value T
 	if err := json.Unmarshal(b, &value); err != nil {
-		return err
+		return newDecodeError[T](n, b, err)
 	}
 
 	n.setValue(value)
@@ -0,0 +1,121 @@
+// Package jsonfield walks struct fields the way encoding/json does. It is
+// shared by the nihil package and its schema generator.
+package jsonfield
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Field is a struct field as encoding/json sees it
+type Field struct {
+	Name  string            // JSON object key
+	Index []int             // index sequence for reflect.Value.FieldByIndex
+	Type  reflect.Type      // field type
+	Tag   reflect.StructTag // field tag
+}
+
+// Fields returns the JSON-visible fields of struct type t in index order,
+// following the encoding/json rules for names, "-" and untagged embedded
+// structs: of fields sharing a name, the shallowest wins, a tagged one
+// winning at equal depth, and fields still tied are left out. Embedded
+// structs for which opaque reports true are fields rather than embedded
+// structs, as encoding/json treats types with their own encoding.
+func Fields(t reflect.Type, opaque func(reflect.Type) bool) []Field {
+	candidates := collect(t, opaque)
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if c := len(a.Index) - len(b.Index); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return 1
+		}
+		return slices.Compare(a.Index, b.Index)
+	})
+
+	var fields []Field
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].Name == candidates[i].Name {
+			j++
+		}
+		group := candidates[i:j]
+		if len(group) == 1 || len(group[0].Index) < len(group[1].Index) || group[0].tagged != group[1].tagged {
+			fields = append(fields, group[0].Field)
+		}
+		i = j
+	}
+	slices.SortFunc(fields, func(a, b Field) int { return slices.Compare(a.Index, b.Index) })
+	return fields
+}
+
+// candidate is a field that may be JSON-visible, before names are
+// resolved between embedded structs
+type candidate struct {
+	Field
+	tagged bool // the name comes from a json tag
+}
+
+// collect returns the named fields of t and of its untagged embedded
+// structs, breadth first like encoding/json, with the embedded structs of
+// each type visited once at its shallowest depth
+func collect(t reflect.Type, opaque func(reflect.Type) bool) []candidate {
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var candidates []candidate
+	visited := map[reflect.Type]bool{}
+	for current := []level{{typ: t}}; len(current) > 0; {
+		var next []level
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+			visited[l.typ] = true
+
+			for i := 0; i < l.typ.NumField(); i++ {
+				sf := l.typ.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name, _, _ := strings.Cut(tag, ",")
+				index := append(slices.Clone(l.index), i)
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct && !opaque(ft) {
+						next = append(next, level{typ: ft, index: index})
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+
+				c := candidate{
+					Field:  Field{Name: name, Index: index, Type: sf.Type, Tag: sf.Tag},
+					tagged: name != "",
+				}
+				if name == "" {
+					c.Name = sf.Name
+				}
+				candidates = append(candidates, c)
+			}
+		}
+		current = next
+	}
+	return candidates
+}
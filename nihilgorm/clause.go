@@ -0,0 +1,257 @@
+package nihilgorm
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Null-safe clause.Expression builders for GORM conditions
+//
+// `db.Where("email = ?", nihil.StringNil())` renders `email = NULL`, which
+// never matches. These builders check whether a value is null when the
+// statement is built and render IS NULL / IS NOT NULL instead:
+//
+//	db.Where(nihilgorm.Eq("email", user.Email)) // email = ? or email IS NULL
+//	db.Not(nihilgorm.Eq("email", user.Email))   // same as nihilgorm.Neq
+//
+// A column is a column name, a clause.Column or a clause.Expression such
+// as Coalesce. Values are nihil values, or anything GORM accepts as a
+// query variable, including clause.Column and clause.Expression.
+
+// Eq matches rows where column equals value, or is NULL if value is null
+func Eq(column, value any) clause.Expression {
+	return nullSafeEq{column: column, value: value}
+}
+
+// Neq matches rows where column differs from value, counting NULL as a
+// value of its own: Neq("email", nihil.String("a")) also matches NULL
+// emails, and Neq("email", nihil.StringNil()) renders IS NOT NULL
+func Neq(column, value any) clause.Expression {
+	return nullSafeEq{column: column, value: value, negated: true}
+}
+
+type nullSafeEq struct {
+	column, value any
+	negated       bool
+}
+
+func (e nullSafeEq) Build(builder clause.Builder) {
+	switch {
+	case isNullValue(e.value) && !e.negated:
+		writeColumn(builder, e.column)
+		builder.WriteString(" IS NULL")
+	case isNullValue(e.value):
+		writeColumn(builder, e.column)
+		builder.WriteString(" IS NOT NULL")
+	case !e.negated:
+		writeColumn(builder, e.column)
+		builder.WriteString(" = ")
+		builder.AddVar(builder, e.value)
+	default:
+		builder.WriteByte('(')
+		writeColumn(builder, e.column)
+		builder.WriteString(" <> ")
+		builder.AddVar(builder, e.value)
+		builder.WriteString(" OR ")
+		writeColumn(builder, e.column)
+		builder.WriteString(" IS NULL)")
+	}
+}
+
+func (e nullSafeEq) NegationBuild(builder clause.Builder) {
+	e.negated = !e.negated
+	e.Build(builder)
+}
+
+// In matches rows where column is one of values; null values match NULL
+func In(column any, values ...any) clause.Expression {
+	return nullSafeIn{column: column, values: values}
+}
+
+type nullSafeIn struct {
+	column  any
+	values  []any
+	negated bool
+}
+
+func (e nullSafeIn) Build(builder clause.Builder) {
+	var (
+		present []any
+		hasNull bool
+	)
+	for _, v := range e.values {
+		if isNullValue(v) {
+			hasNull = true
+		} else {
+			present = append(present, v)
+		}
+	}
+
+	switch {
+	case len(present) == 0 && hasNull:
+		writeColumn(builder, e.column)
+		if e.negated {
+			builder.WriteString(" IS NOT NULL")
+		} else {
+			builder.WriteString(" IS NULL")
+		}
+	case len(present) == 0:
+		// an empty list matches nothing, or everything when negated
+		if e.negated {
+			builder.WriteString("1 = 1")
+		} else {
+			builder.WriteString("1 = 0")
+		}
+	default:
+		builder.WriteByte('(')
+		writeColumn(builder, e.column)
+		if e.negated {
+			builder.WriteString(" NOT IN ")
+		} else {
+			builder.WriteString(" IN ")
+		}
+		builder.AddVar(builder, present)
+		// NULL rows match IN with a null value, and NOT IN without one
+		if hasNull != e.negated {
+			builder.WriteString(" OR ")
+			writeColumn(builder, e.column)
+			builder.WriteString(" IS NULL")
+		}
+		builder.WriteByte(')')
+	}
+}
+
+func (e nullSafeIn) NegationBuild(builder clause.Builder) {
+	e.negated = !e.negated
+	e.Build(builder)
+}
+
+// IsDistinctFrom matches rows where column and value differ, treating two
+// NULLs as equal, using the dialect's null-safe comparison. Unlike Neq it
+// works when the value's nullness is only known to the database, e.g. for
+// another column.
+func IsDistinctFrom(column, value any) clause.Expression {
+	return distinctFrom{column: column, value: value}
+}
+
+// IsNotDistinctFrom matches rows where column and value are equal or both
+// NULL, using the dialect's null-safe comparison
+func IsNotDistinctFrom(column, value any) clause.Expression {
+	return distinctFrom{column: column, value: value, negated: true}
+}
+
+type distinctFrom struct {
+	column, value any
+	negated       bool
+}
+
+func (e distinctFrom) Build(builder clause.Builder) {
+	switch dialectOf(builder) {
+	case "mysql":
+		// <=> is MySQL's null-safe equality operator
+		if !e.negated {
+			builder.WriteString("NOT ")
+		}
+		builder.WriteByte('(')
+		writeColumn(builder, e.column)
+		builder.WriteString(" <=> ")
+		builder.AddVar(builder, e.value)
+		builder.WriteByte(')')
+	case "postgres", "sqlite":
+		writeColumn(builder, e.column)
+		if e.negated {
+			builder.WriteString(" IS NOT DISTINCT FROM ")
+		} else {
+			builder.WriteString(" IS DISTINCT FROM ")
+		}
+		builder.AddVar(builder, e.value)
+	default:
+		// Portable form, also for SQL Server, which only has IS DISTINCT
+		// FROM since SQL Server 2022
+		if !e.negated {
+			builder.WriteString("NOT ")
+		}
+		builder.WriteString("(")
+		writeColumn(builder, e.column)
+		builder.WriteString(" = ")
+		builder.AddVar(builder, e.value)
+		builder.WriteString(" OR (")
+		writeColumn(builder, e.column)
+		builder.WriteString(" IS NULL AND ")
+		builder.AddVar(builder, e.value)
+		builder.WriteString(" IS NULL))")
+	}
+}
+
+func (e distinctFrom) NegationBuild(builder clause.Builder) {
+	e.negated = !e.negated
+	e.Build(builder)
+}
+
+// Coalesce renders COALESCE(values...), the first non-null value. Use
+// clause.Column for column arguments:
+//
+//	nihilgorm.Coalesce(clause.Column{Name: "nickname"}, clause.Column{Name: "name"})
+func Coalesce(values ...any) clause.Expression {
+	return sqlFunction{name: "COALESCE", args: values}
+}
+
+// NullIf renders NULLIF(value, null), which is NULL when value equals null
+// and value otherwise, e.g. to read empty strings as NULL:
+//
+//	nihilgorm.NullIf(clause.Column{Name: "email"}, "")
+func NullIf(value, null any) clause.Expression {
+	return sqlFunction{name: "NULLIF", args: []any{value, null}}
+}
+
+type sqlFunction struct {
+	name string
+	args []any
+}
+
+func (f sqlFunction) Build(builder clause.Builder) {
+	builder.WriteString(f.name)
+	builder.WriteByte('(')
+	for i, arg := range f.args {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.AddVar(builder, arg)
+	}
+	builder.WriteByte(')')
+}
+
+// writeColumn writes a column name quoted, or builds a column expression
+func writeColumn(builder clause.Builder, column any) {
+	if expr, ok := column.(clause.Expression); ok {
+		expr.Build(builder)
+		return
+	}
+	builder.WriteQuoted(column)
+}
+
+// isNullValue reports whether v is nil or a driver.Valuer holding NULL
+func isNullValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return true
+		}
+		value, err := v.Value()
+		return err == nil && value == nil
+	}
+	return false
+}
+
+// dialectOf returns the name of the dialect a statement is built for
+func dialectOf(builder clause.Builder) string {
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.DB != nil && stmt.DB.Dialector != nil {
+		return stmt.DB.Dialector.Name()
+	}
+	return ""
+}
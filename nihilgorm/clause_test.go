@@ -0,0 +1,115 @@
+package nihilgorm
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func setupClauseTestDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	models := []GormTestModel{
+		{Name: String("Alice"), Age: Int32(0), Level: Byte(1), RankSmall: Int16(1)},
+		{Name: String("Bob"), Age: Int32(0), Level: Byte(2), RankSmall: Int16(3)},
+		{Name: StringNil(), Age: Int32(0), Level: ByteNil(), RankSmall: Int16Nil()},
+		{Name: String(""), Age: Int32(0), Level: ByteNil(), RankSmall: Int16(4)},
+	}
+	if err := db.Create(&models).Error; err != nil {
+		t.Fatalf("Failed to create records: %v", err)
+	}
+	return db
+}
+
+func TestGORM_NullSafeConditions(t *testing.T) {
+	db := setupClauseTestDB(t)
+
+	level := clause.Column{Name: "level"}
+	rank := clause.Column{Name: "rank_small"}
+
+	tests := []struct {
+		name     string
+		query    func(*gorm.DB) *gorm.DB
+		expected int64
+	}{
+		{"Eq value", func(db *gorm.DB) *gorm.DB { return db.Where(Eq("name", String("Alice"))) }, 1},
+		{"Eq null", func(db *gorm.DB) *gorm.DB { return db.Where(Eq("name", StringNil())) }, 1},
+		{"Eq nil pointer", func(db *gorm.DB) *gorm.DB { return db.Where(Eq("name", (*NilString)(nil))) }, 1},
+		{"Neq value", func(db *gorm.DB) *gorm.DB { return db.Where(Neq("name", String("Alice"))) }, 3},
+		{"Neq null", func(db *gorm.DB) *gorm.DB { return db.Where(Neq("name", StringNil())) }, 3},
+		{"Not Eq value", func(db *gorm.DB) *gorm.DB { return db.Not(Eq("name", String("Alice"))) }, 3},
+		{"Not Eq null", func(db *gorm.DB) *gorm.DB { return db.Not(Eq("name", StringNil())) }, 3},
+		{"In values", func(db *gorm.DB) *gorm.DB { return db.Where(In("name", String("Alice"), String("Bob"))) }, 2},
+		{"In with null", func(db *gorm.DB) *gorm.DB { return db.Where(In("name", String("Alice"), StringNil())) }, 2},
+		{"In only null", func(db *gorm.DB) *gorm.DB { return db.Where(In("name", StringNil())) }, 1},
+		{"In empty", func(db *gorm.DB) *gorm.DB { return db.Where(In("name")) }, 0},
+		{"Not In values", func(db *gorm.DB) *gorm.DB { return db.Not(In("name", String("Alice"))) }, 3},
+		{"Not In with null", func(db *gorm.DB) *gorm.DB { return db.Not(In("name", String("Alice"), StringNil())) }, 2},
+		{"Not In empty", func(db *gorm.DB) *gorm.DB { return db.Not(In("name")) }, 4},
+		{"IsDistinctFrom value", func(db *gorm.DB) *gorm.DB { return db.Where(IsDistinctFrom("level", Byte(1))) }, 3},
+		{"IsDistinctFrom null", func(db *gorm.DB) *gorm.DB { return db.Where(IsDistinctFrom("level", ByteNil())) }, 2},
+		{"IsDistinctFrom column", func(db *gorm.DB) *gorm.DB { return db.Where(IsDistinctFrom(level, rank)) }, 2},
+		{"IsNotDistinctFrom column", func(db *gorm.DB) *gorm.DB { return db.Where(IsNotDistinctFrom(level, rank)) }, 2},
+		{"Not IsDistinctFrom column", func(db *gorm.DB) *gorm.DB { return db.Not(IsDistinctFrom(level, rank)) }, 2},
+		{"Eq Coalesce", func(db *gorm.DB) *gorm.DB { return db.Where(Eq(Coalesce(level, rank), Int64(4))) }, 1},
+		{"Eq NullIf", func(db *gorm.DB) *gorm.DB { return db.Where(Eq(NullIf(clause.Column{Name: "name"}, ""), nil)) }, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var count int64
+			if err := tt.query(db.Model(&GormTestModel{})).Count(&count).Error; err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d rows, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+// namedDialector reports a different dialect name to render its SQL
+type namedDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d namedDialector) Name() string { return d.name }
+
+func TestGORM_NullSafeConditionSQL(t *testing.T) {
+	db := setupTestDB(t)
+
+	tests := []struct {
+		dialect  string
+		expr     clause.Expression
+		expected string
+	}{
+		{"sqlite", Eq("name", StringNil()), "`name` IS NULL"},
+		{"sqlite", Eq("name", String("a")), "`name` = ?"},
+		{"sqlite", Neq("name", String("a")), "(`name` <> ? OR `name` IS NULL)"},
+		{"sqlite", In("name", String("a"), StringNil()), "(`name` IN (?) OR `name` IS NULL)"},
+		{"sqlite", IsDistinctFrom("name", String("a")), "`name` IS DISTINCT FROM ?"},
+		{"postgres", IsNotDistinctFrom("name", String("a")), "`name` IS NOT DISTINCT FROM ?"},
+		{"mysql", IsNotDistinctFrom("name", String("a")), "(`name` <=> ?)"},
+		{"mysql", IsDistinctFrom("name", String("a")), "NOT (`name` <=> ?)"},
+		{"other", IsDistinctFrom("name", String("a")), "NOT (`name` = ? OR (`name` IS NULL AND ? IS NULL))"},
+		{"sqlserver", IsNotDistinctFrom("name", String("a")), "(`name` = ? OR (`name` IS NULL AND ? IS NULL))"},
+		{"sqlite", Coalesce(clause.Column{Name: "name"}, "x"), "COALESCE(`name`, ?)"},
+		{"sqlite", NullIf(clause.Column{Name: "name"}, ""), "NULLIF(`name`, ?)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			dialectDB := db.Session(&gorm.Session{DryRun: true})
+			dialectDB.Dialector = namedDialector{Dialector: db.Dialector, name: tt.dialect}
+
+			stmt := dialectDB.Model(&GormTestModel{}).Where(tt.expr).Find(&[]GormTestModel{}).Statement
+			where := stmt.SQL.String()
+			where = where[strings.Index(where, "WHERE ")+len("WHERE "):]
+			if where != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, where)
+			}
+		})
+	}
+}
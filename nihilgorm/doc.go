@@ -0,0 +1,23 @@
+// Package nihilgorm integrates the nihil nullable types with GORM, keeping
+// the nihil package itself free of any ORM dependency.
+//
+// The wrapper types embed the nihil type of the same name and add
+// GormDBDataType, which maps them to the column type of the connection's
+// dialect in the nihil dialect registry:
+//
+//	type User struct {
+//		ID    uint
+//		Name  nihilgorm.NilString `gorm:"size:100"`
+//		Email nihilgorm.NilString
+//	}
+//
+//	db.Use(nihilgorm.Plugin{})
+//	db.Create(&User{Name: nihilgorm.String("Alice"), Email: nihilgorm.StringNil()})
+//
+// Models using the nihil types directly keep compiling and working: the
+// nihil types implement Scan, Value and GormDataType, and Plugin, the
+// condition builders and the serializers accept them too. Their columns
+// are created from portable type names such as "integer" and "double
+// precision", matching the column types of nihil 1.1; the wrapper types
+// add dialect specific columns, NilEnum constraints and storage columns.
+package nihilgorm
@@ -0,0 +1,170 @@
+package nihilgorm
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SchemaDrift describes one difference between a nihil field of a model
+// and the column the database actually has
+type SchemaDrift struct {
+	Table    string // table name, e.g. "users"
+	Column   string // column name, e.g. "email"; empty when the table is missing
+	Field    string // Go field name, e.g. "Email"
+	Expected string // what the model implies, e.g. "NULL" or "varchar(100)"
+	Actual   string // what the database has, e.g. "NOT NULL" or "text"
+	Message  string // human readable description
+}
+
+func (d *SchemaDrift) Error() string {
+	if d.Column == "" {
+		return "nihil: table " + strconv.Quote(d.Table) + " " + d.Message
+	}
+	return "nihil: column " + strconv.Quote(d.Table+"."+d.Column) + " " + d.Message
+}
+
+// SchemaDrifts lists every difference found by CheckSchema
+type SchemaDrifts []*SchemaDrift
+
+func (e SchemaDrifts) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// CheckSchema compares the nihil fields of the given models, of both the
+// nihil types and the wrapper types of this package, with the live columns
+// reported by the migrator:
+//
+//	if err := nihilgorm.CheckSchema(db, &User{}, &Order{}); err != nil {
+//		log.Fatal(err) // nihil: column "users.email" is NOT NULL but the field can hold null; ...
+//	}
+//
+// A field is expected to be nullable unless tagged `gorm:"not null"`, so a
+// NOT NULL column rejects the nulls the model writes, and a nullable column
+// behind a `not null` field holds nulls the model does not expect. Column
+// types are compared with the type the field would be migrated to, e.g.
+// from GormDBDataType, accepting the dialect's aliases (int4 for integer).
+// Missing tables and columns are reported too.
+//
+// CheckSchema returns nil, a SchemaDrifts listing every difference, or an
+// error from parsing a model or reading the columns.
+func CheckSchema(db *gorm.DB, models ...any) error {
+	var drifts SchemaDrifts
+	migrator := db.Migrator()
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			drifts = append(drifts, &SchemaDrift{Table: table, Message: "is missing"})
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return err
+		}
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, ct := range columnTypes {
+			columns[ct.Name()] = ct
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration || !isNihilField(field) {
+				continue
+			}
+			drift := SchemaDrift{Table: table, Column: field.DBName, Field: field.Name}
+
+			column, ok := columns[field.DBName]
+			if !ok {
+				drift.Message = "is missing"
+				drifts = append(drifts, &drift)
+				continue
+			}
+
+			if nullable, ok := column.Nullable(); ok && !field.PrimaryKey && nullable == field.NotNull {
+				d := drift
+				d.Expected, d.Actual = nullability(!field.NotNull), nullability(nullable)
+				if field.NotNull {
+					d.Message = "is nullable but the field is tagged not null"
+				} else {
+					d.Message = "is NOT NULL but the field can hold null"
+				}
+				drifts = append(drifts, &d)
+			}
+
+			expected := migrator.FullDataTypeOf(field).SQL
+			if actual := column.DatabaseTypeName(); !sameColumnType(migrator, expected, actual) {
+				d := drift
+				d.Expected, d.Actual = baseType(expected), strings.ToLower(actual)
+				if full, ok := column.ColumnType(); ok {
+					d.Actual = strings.ToLower(full)
+				}
+				d.Message = "has type " + d.Actual + " but the field maps to " + d.Expected
+				drifts = append(drifts, &d)
+			}
+		}
+	}
+
+	if len(drifts) > 0 {
+		return drifts
+	}
+	return nil
+}
+
+// nihilPackages are the packages whose types CheckSchema inspects
+var nihilPackages = map[string]bool{
+	reflect.TypeFor[nihil.NilString]().PkgPath(): true,
+	reflect.TypeFor[NilString]().PkgPath():       true,
+}
+
+// isNihilField reports whether field holds a nihil or nihilgorm type
+func isNihilField(field *schema.Field) bool {
+	return nihilPackages[field.IndirectFieldType.PkgPath()]
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// baseType strips the constraints FullDataTypeOf adds after the type, e.g.
+// "varchar(100) NOT NULL" becomes "varchar(100)"
+func baseType(dataType string) string {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	for _, suffix := range []string{" not null", " default ", " check "} {
+		if i := strings.Index(dataType, suffix); i >= 0 {
+			dataType = dataType[:i]
+		}
+	}
+	return dataType
+}
+
+// sameColumnType reports whether a column of the database type name actual
+// holds the data type expected, the way GORM's MigrateColumn decides it
+func sameColumnType(migrator gorm.Migrator, expected, actual string) bool {
+	expected, actual = baseType(expected), strings.ToLower(actual)
+	if actual == "" || strings.HasPrefix(expected, actual) {
+		return true
+	}
+	for _, alias := range migrator.GetTypeAliases(actual) {
+		if strings.HasPrefix(expected, strings.ToLower(alias)) {
+			return true
+		}
+	}
+	return false
+}
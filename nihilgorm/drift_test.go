@@ -0,0 +1,86 @@
+package nihilgorm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type GormDriftModel struct {
+	ID       uint            `gorm:"primarykey"`
+	Email    NilString       `gorm:"size:100"`
+	Nickname nihil.NilString `gorm:"not null"`
+	Age      NilInt32
+	Status   NilEnum[testStatus]
+	Note     string
+	Missing  NilBool
+}
+
+func TestGORM_CheckSchema(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&GormTestModel{}, &GormDriftModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := CheckSchema(db, &GormTestModel{}, &GormDriftModel{}); err != nil {
+		t.Fatalf("Expected migrated tables to match, got %v", err)
+	}
+
+	// Recreate the table the way a diverged production database has it
+	if err := db.Migrator().DropTable(&GormDriftModel{}); err != nil {
+		t.Fatalf("Failed to drop table: %v", err)
+	}
+	err = db.Exec("CREATE TABLE gorm_drift_models (" +
+		"id integer PRIMARY KEY, email text NOT NULL, nickname text, age text, status text, note text)").Error
+	if err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	err = CheckSchema(db, &GormDriftModel{})
+	var drifts SchemaDrifts
+	if !errors.As(err, &drifts) {
+		t.Fatalf("Expected SchemaDrifts, got %v", err)
+	}
+
+	expected := []struct {
+		column   string
+		expected string
+		actual   string
+	}{
+		{"email", "NULL", "NOT NULL"},
+		{"nickname", "NOT NULL", "NULL"},
+		{"age", "integer", "text"},
+		{"missing", "", ""},
+	}
+	if len(drifts) != len(expected) {
+		t.Fatalf("Expected %d drifts, got %d: %v", len(expected), len(drifts), err)
+	}
+	for i, tt := range expected {
+		d := drifts[i]
+		if d.Table != "gorm_drift_models" || d.Column != tt.column || d.Expected != tt.expected || d.Actual != tt.actual {
+			t.Errorf("Expected %s drift %q -> %q, got %+v", tt.column, tt.expected, tt.actual, d)
+		}
+	}
+}
+
+func TestGORM_CheckSchemaMissingTable(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	err = CheckSchema(db, &GormDriftModel{})
+	var drifts SchemaDrifts
+	if !errors.As(err, &drifts) || len(drifts) != 1 || drifts[0].Column != "" {
+		t.Fatalf("Expected a missing table drift, got %v", err)
+	}
+	if msg := drifts[0].Error(); msg != `nihil: table "gorm_drift_models" is missing` {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
@@ -0,0 +1,821 @@
+package nihilgorm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Test model using nihil types
+type GormTestModel struct {
+	ID        uint       `gorm:"primarykey"`
+	Name      NilString  `gorm:"size:100"`
+	Age       NilInt32   `gorm:"not null"`
+	Score     NilFloat64 `gorm:"precision:2"`
+	Active    NilBool    `gorm:"default:true"`
+	Level     NilByte    `gorm:""`
+	RankSmall NilInt16   `gorm:""`
+	RankLarge NilInt64   `gorm:""`
+	CreatedAt NilTime    `gorm:"precision:6"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime"`
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	err = db.AutoMigrate(&GormTestModel{})
+	if err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	return db
+}
+
+func TestGORM_DataTypes(t *testing.T) {
+	// Test that GORM recognizes our data types correctly
+	tests := []struct {
+		nilType  any
+		expected string
+	}{
+		{NilByte{}, "smallint"},
+		{NilBool{}, "bool"},
+		{NilFloat64{}, "double precision"},
+		{NilInt16{}, "smallint"},
+		{NilInt32{}, "integer"},
+		{NilInt64{}, "bigint"},
+		{NilString{}, "string"},
+		{NilTime{}, "time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if dataTyper, ok := tt.nilType.(interface{ GormDataType() string }); ok {
+				result := dataTyper.GormDataType()
+				if result != tt.expected {
+					t.Errorf("Expected data type %s, got %s", tt.expected, result)
+				}
+			} else {
+				t.Errorf("Type %T does not implement GormDataType", tt.nilType)
+			}
+		})
+	}
+}
+
+func TestGORM_DatabaseTypes(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Get the schema for our test model
+	stmt := &gorm.Statement{DB: db}
+	err := stmt.Parse(&GormTestModel{})
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+
+	// Test field data types
+	tests := []struct {
+		fieldName    string
+		expectedType string
+	}{
+		{"Name", "string"},
+		{"Age", "integer"},
+		{"Score", "double precision"},
+		{"Active", "bool"},
+		{"Level", "smallint"},
+		{"RankSmall", "smallint"},
+		{"RankLarge", "bigint"},
+		{"CreatedAt", "time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldName, func(t *testing.T) {
+			field := stmt.Schema.LookUpField(tt.fieldName)
+			if field == nil {
+				t.Fatalf("Field %s not found in schema", tt.fieldName)
+			}
+			if string(field.DataType) != tt.expectedType {
+				t.Errorf("Field %s: expected data type %s, got %s",
+					tt.fieldName, tt.expectedType, field.DataType)
+			}
+		})
+	}
+}
+
+func TestGORM_CRUD_Operations(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Create test record with valid values
+	now := time.Now()
+	model := GormTestModel{
+		Name:      String("Alice"),
+		Age:       Int32(25),
+		Score:     Float64(95.5),
+		Active:    Bool(true),
+		Level:     Byte(10),
+		RankSmall: Int16(100),
+		RankLarge: Int64(1000),
+		CreatedAt: Time(now),
+	}
+
+	// Create
+	result := db.Create(&model)
+	if result.Error != nil {
+		t.Fatalf("Failed to create record: %v", result.Error)
+	}
+
+	// Read
+	var retrieved GormTestModel
+	result = db.First(&retrieved, model.ID)
+	if result.Error != nil {
+		t.Fatalf("Failed to retrieve record: %v", result.Error)
+	}
+
+	// Verify all fields
+	if !retrieved.Name.Valid || retrieved.Name.String != "Alice" {
+		t.Error("Name field mismatch")
+	}
+	if !retrieved.Age.Valid || retrieved.Age.Int32 != 25 {
+		t.Error("Age field mismatch")
+	}
+	if !retrieved.Score.Valid || retrieved.Score.Float64 != 95.5 {
+		t.Error("Score field mismatch")
+	}
+	if !retrieved.Active.Valid || !retrieved.Active.Bool {
+		t.Error("Active field mismatch")
+	}
+	if !retrieved.Level.Valid || retrieved.Level.Byte != 10 {
+		t.Error("Level field mismatch")
+	}
+	if !retrieved.RankSmall.Valid || retrieved.RankSmall.Int16 != 100 {
+		t.Error("RankSmall field mismatch")
+	}
+	if !retrieved.RankLarge.Valid || retrieved.RankLarge.Int64 != 1000 {
+		t.Error("RankLarge field mismatch")
+	}
+
+	// Update with null values
+	result = db.Model(&retrieved).Updates(GormTestModel{
+		Name:      StringNil(),
+		Age:       Int32Nil(),
+		Score:     Float64Nil(),
+		Active:    BoolNil(),
+		Level:     ByteNil(),
+		RankSmall: Int16Nil(),
+		RankLarge: Int64Nil(),
+		CreatedAt: TimeNil(),
+	})
+	if result.Error != nil {
+		t.Fatalf("Failed to update record: %v", result.Error)
+	}
+
+	// Read updated record
+	var updated GormTestModel
+	result = db.First(&updated, model.ID)
+	if result.Error != nil {
+		t.Fatalf("Failed to retrieve updated record: %v", result.Error)
+	}
+
+	// Verify null values (GORM might not update all fields to null depending on configuration)
+	// This test verifies the basic functionality works
+}
+
+func TestGORM_NullValues(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Create record with null values
+	model := GormTestModel{
+		Name:      StringNil(),
+		Age:       Int32(0), // Required field, can't be null due to gorm tag
+		Score:     Float64Nil(),
+		Active:    BoolNil(),
+		Level:     ByteNil(),
+		RankSmall: Int16Nil(),
+		RankLarge: Int64Nil(),
+		CreatedAt: TimeNil(),
+	}
+
+	result := db.Create(&model)
+	if result.Error != nil {
+		t.Fatalf("Failed to create record with nulls: %v", result.Error)
+	}
+
+	// Retrieve and verify
+	var retrieved GormTestModel
+	result = db.First(&retrieved, model.ID)
+	if result.Error != nil {
+		t.Fatalf("Failed to retrieve record: %v", result.Error)
+	}
+
+	// Check that null fields are properly handled
+	if retrieved.Name.Valid {
+		t.Error("Name should be null")
+	}
+	if retrieved.Score.Valid {
+		t.Error("Score should be null")
+	}
+	if retrieved.Level.Valid {
+		t.Error("Level should be null")
+	}
+}
+
+type GormTimeModel struct {
+	ID       uint    `gorm:"primarykey"`
+	Stamp    NilTime `gorm:"precision:6" nihil:"tz=UTC"`
+	Seconds  NilTime `gorm:"precision:0"`
+	Untagged NilTime
+}
+
+func TestGORM_TimeNormalization(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&GormTimeModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	local := time.FixedZone("UTC+7", 7*60*60)
+	written := time.Date(2023, 10, 15, 21, 30, 0, 123456789, local)
+	model := GormTimeModel{Stamp: Time(written), Seconds: Time(written), Untagged: TimeNil()}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	expected := time.Date(2023, 10, 15, 14, 30, 0, 123456000, time.UTC)
+	if model.Stamp.Time != expected {
+		t.Errorf("Expected model to hold %v after create, got %v", expected, model.Stamp.Time)
+	}
+	if !model.Seconds.Time.Equal(written.Truncate(time.Second)) {
+		t.Errorf("Expected seconds precision, got %v", model.Seconds.Time)
+	}
+
+	var retrieved GormTimeModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.Stamp != model.Stamp {
+		t.Errorf("Round trip mismatch: wrote %v, read %v", model.Stamp.Time, retrieved.Stamp.Time)
+	}
+	if !retrieved.Seconds.Time.Equal(model.Seconds.Time) {
+		t.Errorf("Round trip mismatch: wrote %v, read %v", model.Seconds.Time, retrieved.Seconds.Time)
+	}
+	if retrieved.Untagged.Valid {
+		t.Error("Untagged should be null")
+	}
+}
+
+func TestGORM_TimeNormalizationInvalidZone(t *testing.T) {
+	type BadZone struct {
+		ID    uint
+		Stamp NilTime `nihil:"tz=Nowhere/Special"`
+	}
+
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&BadZone{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(&BadZone{Stamp: Time(time.Now())}).Error; err == nil {
+		t.Error("Expected error for unknown time zone")
+	}
+}
+
+type GormTimeStorageModel struct {
+	ID       uint    `gorm:"primarykey"`
+	Millis   NilTime `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+	Seconds  NilTime `gorm:"serializer:nihiltime" nihil:"storage=unix"`
+	Text     NilTime `gorm:"serializer:nihiltime" nihil:"storage=text"`
+	Nullable NilTime `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+	Temporal NilTime
+}
+
+func TestGORM_TimeStorage(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&GormTimeStorageModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(&GormTimeStorageModel{})
+	if err != nil {
+		t.Fatalf("Failed to read column types: %v", err)
+	}
+	expectedTypes := map[string]string{
+		"millis": "INTEGER", "seconds": "INTEGER", "text": "TEXT", "temporal": "DATETIME",
+	}
+	for _, ct := range columnTypes {
+		if expected, ok := expectedTypes[ct.Name()]; ok && ct.DatabaseTypeName() != expected {
+			t.Errorf("Column %s: expected %s, got %s", ct.Name(), expected, ct.DatabaseTypeName())
+		}
+	}
+
+	written := time.Date(2023, 10, 15, 14, 30, 0, 123000000, time.UTC)
+	model := GormTimeStorageModel{
+		Millis:   Time(written),
+		Seconds:  Time(written),
+		Text:     Time(written),
+		Nullable: TimeNil(),
+		Temporal: Time(written),
+	}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	var raw struct {
+		Millis   any
+		Seconds  any
+		Text     any
+		Nullable any
+	}
+	if err := db.Raw("SELECT millis, seconds, text, nullable FROM gorm_time_storage_models WHERE id = ?", model.ID).
+		Row().Scan(&raw.Millis, &raw.Seconds, &raw.Text, &raw.Nullable); err != nil {
+		t.Fatalf("Failed to read raw columns: %v", err)
+	}
+	if raw.Millis != written.UnixMilli() {
+		t.Errorf("Expected millis %d, got %v", written.UnixMilli(), raw.Millis)
+	}
+	if raw.Seconds != written.Unix() {
+		t.Errorf("Expected seconds %d, got %v", written.Unix(), raw.Seconds)
+	}
+	if raw.Text != "2023-10-15T14:30:00.123000000Z" {
+		t.Errorf("Expected RFC 3339 text, got %v", raw.Text)
+	}
+	if raw.Nullable != nil {
+		t.Errorf("Expected NULL, got %v", raw.Nullable)
+	}
+
+	var retrieved GormTimeStorageModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if !retrieved.Millis.Valid || !retrieved.Millis.Time.Equal(written) {
+		t.Errorf("Millis mismatch: %v", retrieved.Millis)
+	}
+	if !retrieved.Seconds.Valid || !retrieved.Seconds.Time.Equal(written.Truncate(time.Second)) {
+		t.Errorf("Seconds mismatch: %v", retrieved.Seconds)
+	}
+	if !retrieved.Text.Valid || !retrieved.Text.Time.Equal(written) {
+		t.Errorf("Text mismatch: %v", retrieved.Text)
+	}
+	if retrieved.Nullable.Valid {
+		t.Error("Nullable should be null")
+	}
+	if !retrieved.Temporal.Valid || !retrieved.Temporal.Time.Equal(written) {
+		t.Errorf("Temporal mismatch: %v", retrieved.Temporal)
+	}
+
+	later := written.Add(time.Hour)
+	if err := db.Model(&retrieved).Updates(GormTimeStorageModel{Millis: Time(later)}).Error; err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+	var count int64
+	db.Model(&GormTimeStorageModel{}).Where("millis = ?", nihil.StorageUnixMilli.Valuer(nihil.Time(later))).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected updated millis to match, got %d rows", count)
+	}
+
+	if err := db.Model(&retrieved).Updates(map[string]any{"millis": Time(written)}).Error; err != nil {
+		t.Fatalf("Failed to update record from a map: %v", err)
+	}
+	db.Model(&GormTimeStorageModel{}).Where("millis = ?", written.UnixMilli()).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected map values to be encoded, got %d rows", count)
+	}
+}
+
+func TestGORM_TimeStorageNeedsSerializer(t *testing.T) {
+	type Unserialized struct {
+		ID     uint
+		Millis NilTime `nihil:"storage=unixmilli"`
+	}
+
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&Unserialized{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(&Unserialized{Millis: Time(time.Now())}).Error; err == nil {
+		t.Error("Expected error for a storage option without the nihiltime serializer")
+	}
+}
+
+type GormStorageOwner struct {
+	ID      uint                `gorm:"primarykey"`
+	SeenAt  NilTime             `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+	Entries []GormStorageEntry  `gorm:"foreignKey:OwnerID"`
+	Profile *GormStorageProfile `gorm:"foreignKey:OwnerID"`
+}
+
+type GormStorageEntry struct {
+	ID       uint `gorm:"primarykey"`
+	OwnerID  uint
+	LoggedAt NilTime `gorm:"serializer:nihiltime" nihil:"storage=unix"`
+}
+
+type GormStorageProfile struct {
+	ID       uint `gorm:"primarykey"`
+	OwnerID  uint
+	JoinedAt *NilTime `gorm:"serializer:nihiltime" nihil:"storage=text"`
+}
+
+func TestGORM_TimeStorageRelations(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&GormStorageOwner{}, &GormStorageEntry{}, &GormStorageProfile{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	written := time.Date(2023, 10, 15, 14, 30, 0, 0, time.UTC)
+	joined := Time(written)
+	owner := GormStorageOwner{
+		SeenAt:  Time(written),
+		Entries: []GormStorageEntry{{LoggedAt: Time(written)}, {LoggedAt: TimeNil()}},
+		Profile: &GormStorageProfile{JoinedAt: &joined},
+	}
+	if err := db.Create(&owner).Error; err != nil {
+		t.Fatalf("Failed to create record with associations: %v", err)
+	}
+
+	var raw struct {
+		LoggedAt any
+		JoinedAt any
+	}
+	if err := db.Raw("SELECT logged_at FROM gorm_storage_entries WHERE id = ?", owner.Entries[0].ID).Row().Scan(&raw.LoggedAt); err != nil {
+		t.Fatalf("Failed to read raw column: %v", err)
+	}
+	if raw.LoggedAt != written.Unix() {
+		t.Errorf("Expected associated entry to store %d, got %v", written.Unix(), raw.LoggedAt)
+	}
+	if err := db.Raw("SELECT joined_at FROM gorm_storage_profiles WHERE id = ?", owner.Profile.ID).Row().Scan(&raw.JoinedAt); err != nil {
+		t.Fatalf("Failed to read raw column: %v", err)
+	}
+	if raw.JoinedAt != "2023-10-15T14:30:00.000000000Z" {
+		t.Errorf("Expected associated profile to store RFC 3339 text, got %v", raw.JoinedAt)
+	}
+
+	var preloaded GormStorageOwner
+	if err := db.Preload("Entries").Preload("Profile").First(&preloaded, owner.ID).Error; err != nil {
+		t.Fatalf("Failed to preload record: %v", err)
+	}
+	if !preloaded.SeenAt.Time.Equal(written) {
+		t.Errorf("Expected SeenAt %v, got %v", written, preloaded.SeenAt)
+	}
+	if len(preloaded.Entries) != 2 || !preloaded.Entries[0].LoggedAt.Time.Equal(written) || preloaded.Entries[1].LoggedAt.Valid {
+		t.Errorf("Expected preloaded entries to be decoded, got %+v", preloaded.Entries)
+	}
+	if preloaded.Profile == nil || preloaded.Profile.JoinedAt == nil || !preloaded.Profile.JoinedAt.Time.Equal(written) {
+		t.Errorf("Expected preloaded profile to be decoded, got %+v", preloaded.Profile)
+	}
+
+	type seen struct {
+		ID     uint
+		SeenAt NilTime `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+	}
+	var summaries []seen
+	if err := db.Model(&GormStorageOwner{}).Find(&summaries).Error; err != nil {
+		t.Fatalf("Failed to find into another struct: %v", err)
+	}
+	if len(summaries) != 1 || !summaries[0].SeenAt.Time.Equal(written) {
+		t.Errorf("Expected SeenAt %v in another struct, got %+v", written, summaries)
+	}
+
+	later := written.Add(time.Hour)
+	if err := db.Model(&preloaded).Updates(seen{SeenAt: Time(later)}).Error; err != nil {
+		t.Fatalf("Failed to update from another struct: %v", err)
+	}
+	var count int64
+	db.Model(&GormStorageOwner{}).Where("seen_at = ?", later.UnixMilli()).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected updates from another struct to be encoded, got %d rows", count)
+	}
+}
+
+type GormAutoTimeModel struct {
+	ID           uint      `gorm:"primarykey"`
+	Name         NilString `gorm:""`
+	CreatedAt    NilTime   `gorm:"precision:3" nihil:"tz=UTC"`
+	UpdatedAt    NilTime   `gorm:""`
+	CreatedMilli NilInt64  `gorm:"autoCreateTime:milli"`
+	UpdatedNano  NilInt64  `gorm:"autoUpdateTime:nano"`
+	TouchedAt    NilTime   `gorm:"autoUpdateTime;serializer:nihiltime" nihil:"storage=unixmilli"`
+}
+
+type GormAutoTimeUnixModel struct {
+	ID        uint     `gorm:"primarykey"`
+	CreatedAt NilInt64 `gorm:""`
+	UpdatedAt NilInt64 `gorm:""`
+}
+
+func TestGORM_AutoTime(t *testing.T) {
+	local := time.FixedZone("UTC+7", 7*60*60)
+	now := time.Date(2023, 10, 15, 21, 30, 0, 123456789, local)
+
+	db := setupTestDB(t)
+	db.Config.NowFunc = func() time.Time { return now }
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&GormAutoTimeModel{}, &GormAutoTimeUnixModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	model := GormAutoTimeModel{Name: String("Alice")}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	created := now.Truncate(time.Millisecond).UTC()
+	if !model.CreatedAt.Valid || model.CreatedAt.Time != created {
+		t.Errorf("Expected CreatedAt %v, got %v", created, model.CreatedAt)
+	}
+	if !model.UpdatedAt.Valid || !model.UpdatedAt.Time.Equal(now) {
+		t.Errorf("Expected UpdatedAt %v, got %v", now, model.UpdatedAt)
+	}
+	if !model.CreatedMilli.Valid || model.CreatedMilli.Int64 != now.UnixMilli() {
+		t.Errorf("Expected CreatedMilli %d, got %v", now.UnixMilli(), model.CreatedMilli)
+	}
+	if !model.UpdatedNano.Valid || model.UpdatedNano.Int64 != now.UnixNano() {
+		t.Errorf("Expected UpdatedNano %d, got %v", now.UnixNano(), model.UpdatedNano)
+	}
+
+	var retrieved GormAutoTimeModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.CreatedAt != model.CreatedAt || retrieved.CreatedMilli != model.CreatedMilli {
+		t.Errorf("Round trip mismatch: wrote %+v, read %+v", model, retrieved)
+	}
+	if !retrieved.TouchedAt.Valid || !retrieved.TouchedAt.Time.Equal(now.Truncate(time.Millisecond)) {
+		t.Errorf("Expected TouchedAt %v, got %v", now, retrieved.TouchedAt)
+	}
+
+	now = now.Add(time.Hour)
+	if err := db.Model(&retrieved).Update("name", "Bob").Error; err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+
+	var updated GormAutoTimeModel
+	if err := db.First(&updated, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve updated record: %v", err)
+	}
+	if updated.CreatedAt != model.CreatedAt || updated.CreatedMilli != model.CreatedMilli {
+		t.Errorf("Expected create times to be kept, got %+v", updated)
+	}
+	if !updated.UpdatedAt.Time.Equal(now) {
+		t.Errorf("Expected UpdatedAt %v, got %v", now, updated.UpdatedAt)
+	}
+	if updated.UpdatedNano.Int64 != now.UnixNano() {
+		t.Errorf("Expected UpdatedNano %d, got %d", now.UnixNano(), updated.UpdatedNano.Int64)
+	}
+	if !updated.TouchedAt.Time.Equal(now.Truncate(time.Millisecond)) {
+		t.Errorf("Expected TouchedAt %v, got %v", now, updated.TouchedAt)
+	}
+
+	unix := GormAutoTimeUnixModel{}
+	if err := db.Create(&unix).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if unix.CreatedAt.Int64 != now.Unix() || unix.UpdatedAt.Int64 != now.Unix() {
+		t.Errorf("Expected Unix seconds %d, got %v and %v", now.Unix(), unix.CreatedAt, unix.UpdatedAt)
+	}
+}
+
+type testStatus string
+
+const (
+	statusActive   testStatus = "active"
+	statusInactive testStatus = "inactive"
+)
+
+func init() {
+	nihil.RegisterEnum(statusActive, statusInactive)
+}
+
+type GormEnumModel struct {
+	ID     uint                `gorm:"primarykey"`
+	Status NilEnum[testStatus] `gorm:""`
+}
+
+func TestGORM_Enum(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&GormEnumModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.AutoMigrate(&GormEnumModel{}); err != nil {
+		t.Fatalf("Failed to migrate database twice: %v", err)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&GormEnumModel{}); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	field := stmt.Schema.LookUpField("Status")
+	expected := "TEXT CHECK (`status` IN ('active', 'inactive'))"
+	if dataType := (NilEnum[testStatus]{}).GormDBDataType(db, field); dataType != expected {
+		t.Errorf("Expected %s, got %s", expected, dataType)
+	}
+
+	model := GormEnumModel{Status: Enum(statusActive)}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if err := db.Create(&GormEnumModel{Status: EnumNil[testStatus]()}).Error; err != nil {
+		t.Fatalf("Failed to create record with null: %v", err)
+	}
+	if err := db.Create(&GormEnumModel{Status: Enum(testStatus("actve"))}).Error; err == nil {
+		t.Error("Expected Value to reject an unknown value")
+	}
+	if err := db.Exec("INSERT INTO gorm_enum_models (status) VALUES ('actve')").Error; err == nil {
+		t.Error("Expected CHECK constraint to reject an unknown value")
+	}
+
+	var retrieved GormEnumModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if !retrieved.Status.Valid || retrieved.Status.Enum != statusActive {
+		t.Errorf("Status mismatch: %+v", retrieved.Status)
+	}
+}
+
+// GormRootTypesModel uses the nihil types directly, as models written
+// before the GORM integration moved to this package do
+type GormRootTypesModel struct {
+	ID     uint            `gorm:"primarykey"`
+	Name   nihil.NilString `gorm:"size:100"`
+	Age    nihil.NilInt32
+	Millis nihil.NilTime `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+}
+
+func TestGORM_RootTypes(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if err := db.AutoMigrate(&GormRootTypesModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	written := time.Date(2023, 10, 15, 14, 30, 0, 123000000, time.UTC)
+	model := GormRootTypesModel{Name: nihil.String("Alice"), Age: nihil.Int32Nil(), Millis: nihil.Time(written)}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	var count int64
+	db.Model(&GormRootTypesModel{}).Where("millis = ?", written.UnixMilli()).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected millis to be stored as %d, got %d rows", written.UnixMilli(), count)
+	}
+
+	var retrieved GormRootTypesModel
+	if err := db.Where(Eq("age", nihil.Int32Nil())).First(&retrieved).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.Name != model.Name || retrieved.Age.Valid || !retrieved.Millis.Time.Equal(written) {
+		t.Errorf("Round trip mismatch: wrote %+v, read %+v", model, retrieved)
+	}
+}
+
+// GormMigrateModel holds each numeric type both as the nihil type and as
+// the wrapper type, whose column is the 1.1 column type
+type GormMigrateModel struct {
+	ID          uint `gorm:"primarykey"`
+	Level       nihil.NilByte
+	LevelType   NilByte
+	Age         nihil.NilInt32
+	AgeType     NilInt32
+	Score       nihil.NilFloat64
+	ScoreType   NilFloat64
+	Rank        nihil.NilInt16
+	RankType    NilInt16
+	Counter     nihil.NilInt64
+	CounterType NilInt64
+}
+
+func TestGORM_RootTypesAutoMigrate(t *testing.T) {
+	// The nihil types' columns are synonyms of the 1.1 column types, or
+	// wider ones where no type name is portable
+	tests := map[string]map[string][2]string{
+		"mysql": {
+			"level": {"SMALLINT", "TINYINT UNSIGNED"},
+			"age":   {"INTEGER", "INT"},
+			"score": {"DOUBLE PRECISION", "DOUBLE"},
+		},
+		"postgres": {
+			"level": {"SMALLINT", "SMALLINT"},
+			"age":   {"INTEGER", "INTEGER"},
+			"score": {"DOUBLE PRECISION", "DOUBLE PRECISION"},
+		},
+		"sqlite": {
+			"level": {"SMALLINT", "INTEGER"},
+			"age":   {"INTEGER", "INTEGER"},
+			"score": {"DOUBLE PRECISION", "REAL"},
+		},
+		"sqlserver": {
+			"level": {"SMALLINT", "TINYINT"},
+			"age":   {"INTEGER", "INT"},
+			"score": {"DOUBLE PRECISION", "FLOAT"},
+		},
+	}
+
+	for dialect, columns := range tests {
+		t.Run(dialect, func(t *testing.T) {
+			db, err := gorm.Open(namedDialector{Dialector: sqlite.Open(":memory:"), name: dialect}, &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to connect to database: %v", err)
+			}
+			if err := db.AutoMigrate(&GormMigrateModel{}); err != nil {
+				t.Fatalf("Failed to migrate database: %v", err)
+			}
+			if err := db.AutoMigrate(&GormMigrateModel{}); err != nil {
+				t.Fatalf("Failed to migrate database twice: %v", err)
+			}
+
+			// The types CREATE TABLE and AutoMigrate's comparison use
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(&GormMigrateModel{}); err != nil {
+				t.Fatalf("Failed to parse schema: %v", err)
+			}
+			declared := map[string]string{}
+			for _, field := range stmt.Schema.Fields {
+				declared[field.DBName] = strings.ToUpper(db.Migrator().(interface {
+					DataTypeOf(*schema.Field) string
+				}).DataTypeOf(field))
+			}
+
+			for column, expected := range columns {
+				if declared[column] != expected[0] {
+					t.Errorf("Column %s: expected %s, got %s", column, expected[0], declared[column])
+				}
+				if declared[column+"_type"] != expected[1] {
+					t.Errorf("Column %s_type: expected %s, got %s", column, expected[1], declared[column+"_type"])
+				}
+			}
+			// SQLite's INTEGER affinity covers SMALLINT and BIGINT
+			for _, column := range []string{"rank", "counter"} {
+				if declared[column] != declared[column+"_type"] && dialect != "sqlite" {
+					t.Errorf("Column %s: expected the 1.1 type %s, got %s", column, declared[column+"_type"], declared[column])
+				}
+			}
+		})
+	}
+}
+
+type GormColumnOptionsModel struct {
+	ID      uint      `gorm:"primarykey"`
+	Country NilString `gorm:"size:2;fixed;charset:ascii;collate:ascii_bin"`
+	Body    NilString `gorm:"text:medium"`
+	Local   NilTime   `gorm:"timezone:false;precision:3"`
+}
+
+func TestGORM_ColumnOptions(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		field    string
+		expected string
+	}{
+		{"mysql", "Country", "CHAR(2) CHARACTER SET ascii COLLATE ascii_bin"},
+		{"sqlserver", "Country", "NCHAR(2) COLLATE ascii_bin"},
+		{"mysql", "Body", "MEDIUMTEXT"},
+		{"postgres", "Body", "TEXT"},
+		{"postgres", "Local", "TIMESTAMP(3)"},
+		{"mysql", "Local", "DATETIME(3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+tt.field, func(t *testing.T) {
+			db, err := gorm.Open(namedDialector{Dialector: sqlite.Open(":memory:"), name: tt.dialect}, &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to connect to database: %v", err)
+			}
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(&GormColumnOptionsModel{}); err != nil {
+				t.Fatalf("Failed to parse schema: %v", err)
+			}
+
+			field := stmt.Schema.LookUpField(tt.field)
+			dataType := db.Migrator().(interface {
+				DataTypeOf(*schema.Field) string
+			}).DataTypeOf(field)
+			if dataType != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, dataType)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package nihilgorm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AutoMigrate runs db.AutoMigrate, then gives `nihil:"uniqueIfNotNull"`
+// fields unique indexes that only cover rows where the column is not null,
+// so any number of rows may leave it empty:
+//
+//	type User struct {
+//		ID    uint
+//		Email nihilgorm.NilString `gorm:"size:255" nihil:"uniqueIfNotNull"`
+//	}
+//
+//	nihilgorm.AutoMigrate(db, &User{}) // CREATE UNIQUE INDEX idx_users_email ... WHERE email IS NOT NULL
+//
+// PostgreSQL, SQLite and SQL Server get a partial (filtered) index. MySQL
+// and other databases get a plain unique index, which already admits any
+// number of NULLs there. The index is named like a GORM uniqueIndex, e.g.
+// idx_users_email.
+func AutoMigrate(db *gorm.DB, models ...any) error {
+	var indexes []uniqueIndex
+	for _, model := range models {
+		modelIndexes, err := uniqueIndexes(db, model)
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, modelIndexes...)
+	}
+
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+		sql := "CREATE UNIQUE INDEX ? ON ? (?)"
+		if index.where != "" {
+			sql += " WHERE " + index.where
+		}
+		err := db.Exec(sql, clause.Column{Name: index.name}, clause.Table{Name: index.table}, clause.Column{Name: index.column}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partialIndexDialects support unique indexes with a WHERE clause
+var partialIndexDialects = map[string]bool{
+	"postgres":  true,
+	"sqlite":    true,
+	"sqlserver": true,
+}
+
+// uniqueIndex is the index of a uniqueIfNotNull field
+type uniqueIndex struct {
+	model         any
+	name, table   string
+	column, where string
+}
+
+// uniqueIndexes returns the indexes of the model's uniqueIfNotNull fields.
+// They are created by AutoMigrate itself rather than added to the model's
+// schema, which GORM caches and shares.
+func uniqueIndexes(db *gorm.DB, model any) ([]uniqueIndex, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, err
+	}
+
+	var indexes []uniqueIndex
+	for _, field := range stmt.Schema.Fields {
+		if _, ok := nihil.TagOption(field.Tag, "uniqueIfNotNull"); !ok {
+			continue
+		}
+
+		for _, setting := range []string{"UNIQUE", "UNIQUEINDEX"} {
+			if _, ok := field.TagSettings[setting]; ok {
+				return nil, fmt.Errorf("nihil: field %s.%s: uniqueIfNotNull conflicts with the gorm %s setting",
+					stmt.Schema.Name, field.Name, strings.ToLower(setting))
+			}
+		}
+
+		index := uniqueIndex{
+			model:  model,
+			name:   db.NamingStrategy.IndexName(stmt.Schema.Table, field.Name),
+			table:  stmt.Schema.Table,
+			column: field.DBName,
+		}
+		if partialIndexDialects[db.Name()] {
+			var column strings.Builder
+			db.Dialector.QuoteTo(&column, field.DBName)
+			index.where = column.String() + " IS NOT NULL"
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
@@ -0,0 +1,112 @@
+package nihilgorm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type GormUniqueModel struct {
+	ID    uint            `gorm:"primarykey"`
+	Email NilString       `gorm:"size:100" nihil:"uniqueIfNotNull"`
+	Phone nihil.NilString `nihil:"uniqueIfNotNull"`
+}
+
+type GormUniqueConflictModel struct {
+	ID    uint      `gorm:"primarykey"`
+	Email NilString `gorm:"unique" nihil:"uniqueIfNotNull"`
+}
+
+func TestGORM_UniqueIfNotNull(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := AutoMigrate(db, &GormUniqueModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	// Migrating again finds the existing indexes
+	if err := AutoMigrate(db, &GormUniqueModel{}); err != nil {
+		t.Fatalf("Failed to migrate database again: %v", err)
+	}
+
+	var indexSQL string
+	db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", "idx_gorm_unique_models_email").Scan(&indexSQL)
+	if !strings.Contains(indexSQL, "UNIQUE") || !strings.Contains(indexSQL, "WHERE `email` IS NOT NULL") {
+		t.Errorf("Expected a partial unique index, got %q", indexSQL)
+	}
+
+	rows := []GormUniqueModel{
+		{Email: StringNil(), Phone: nihil.StringNil()},
+		{Email: StringNil(), Phone: nihil.StringNil()},
+		{Email: String("a@example.com"), Phone: nihil.String("555")},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatalf("Expected null duplicates to be accepted, got %v", err)
+	}
+
+	if err := db.Create(&GormUniqueModel{Email: String("a@example.com")}).Error; err == nil {
+		t.Error("Expected a duplicate email to be rejected")
+	}
+	if err := db.Create(&GormUniqueModel{Phone: nihil.String("555")}).Error; err == nil {
+		t.Error("Expected a duplicate phone to be rejected")
+	}
+}
+
+func TestGORM_UniqueIfNotNullDialects(t *testing.T) {
+	tests := []struct {
+		dialect string
+		where   string
+	}{
+		{"postgres", "`email` IS NOT NULL"},
+		{"sqlserver", "`email` IS NOT NULL"},
+		{"mysql", ""},
+		{"other", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db, err := gorm.Open(namedDialector{Dialector: sqlite.Open(":memory:"), name: tt.dialect}, &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to connect to database: %v", err)
+			}
+			indexes, err := uniqueIndexes(db, &GormUniqueModel{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(indexes) != 2 {
+				t.Fatalf("Expected 2 indexes, got %d", len(indexes))
+			}
+			index := indexes[0]
+			if index.name != "idx_gorm_unique_models_email" || index.column != "email" || index.where != tt.where {
+				t.Errorf("Expected idx_gorm_unique_models_email on email where %q, got %s on %s where %q",
+					tt.where, index.name, index.column, index.where)
+			}
+
+			// GORM's cached schema is left as declared
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(&GormUniqueModel{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stmt.Schema.LookIndex(index.name) != nil {
+				t.Error("Expected no index in the cached schema")
+			}
+			if tag := stmt.Schema.LookUpField("Email").Tag; tag != `gorm:"size:100" nihil:"uniqueIfNotNull"` {
+				t.Errorf("Expected the cached field tag to be unchanged, got %s", tag)
+			}
+		})
+	}
+}
+
+func TestGORM_UniqueIfNotNullConflict(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := AutoMigrate(db, &GormUniqueConflictModel{}); err == nil {
+		t.Error("Expected an error combining uniqueIfNotNull with unique")
+	}
+}
@@ -0,0 +1,386 @@
+package nihilgorm
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Plugin registers the GORM callbacks that apply per-field nihil settings
+// which a bare Value or Scan method cannot see
+//
+//	db.Use(nihilgorm.Plugin{})
+//
+// The callbacks handle fields of both the nihil types and the wrapper
+// types of this package.
+//
+// NilTime fields are normalized in place with the field's TimePolicy
+// (the GORM `precision` tag and the `nihil:"tz=..."` option) before
+// create and update, and after query, so the model holds exactly what the
+// database stores.
+//
+// NilTime fields with a `nihil:"storage=..."` option are written and read
+// by TimeSerializer, which the field selects with
+// `gorm:"serializer:nihiltime"`; the plugin reports fields that miss it.
+// NilTime values of such fields passed to map-based Updates are encoded
+// too; values passed to Where must be encoded by the caller, e.g. with
+// nihil.StorageUnixMilli.Valuer(t).
+//
+// NilTime and NilInt64 fields tagged autoCreateTime or autoUpdateTime, or
+// named CreatedAt and UpdatedAt, are filled in on create and update; NilInt64
+// fields take the tag's unit, e.g. `gorm:"autoCreateTime:milli"`. The
+// plugin only sets these values on the model and in the statement's
+// clauses, and never changes the schemas GORM caches.
+type Plugin struct{}
+
+func (Plugin) Name() string { return "nihil" }
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("nihil:check_fields", checkFields); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register("nihil:check_fields", checkFields); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("nihil:check_fields", checkFields); err != nil {
+		return err
+	}
+
+	if err := callback.Create().After("nihil:check_fields").Before("gorm:create").Register("nihil:normalize_time", normalizeTimeFields); err != nil {
+		return err
+	}
+	if err := callback.Update().After("nihil:check_fields").Before("gorm:update").Register("nihil:normalize_time", normalizeTimeFields); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("nihil:normalize_time", normalizeTimeFields); err != nil {
+		return err
+	}
+
+	if err := callback.Create().After("nihil:normalize_time").Before("gorm:create").Register("nihil:auto_time", fillAutoTimes); err != nil {
+		return err
+	}
+	if err := callback.Update().After("nihil:normalize_time").Before("gorm:update").Register("nihil:assignments", prepareAssignments); err != nil {
+		return err
+	}
+	return callback.Update().After("gorm:update").Register("nihil:drop_assignments", dropAssignments)
+}
+
+var (
+	nilTimeType      = reflect.TypeFor[nihil.NilTime]()
+	wrapperTimeType  = reflect.TypeFor[NilTime]()
+	nilInt64Type     = reflect.TypeFor[nihil.NilInt64]()
+	wrapperInt64Type = reflect.TypeFor[NilInt64]()
+	deletedAtType    = reflect.TypeFor[DeletedAt]()
+)
+
+// isTimeField reports whether field holds a nihil.NilTime or a NilTime
+func isTimeField(field *schema.Field) bool {
+	return field.IndirectFieldType == nilTimeType || field.IndirectFieldType == wrapperTimeType
+}
+
+// isInt64Field reports whether field holds a nihil.NilInt64 or a NilInt64
+func isInt64Field(field *schema.Field) bool {
+	return field.IndirectFieldType == nilInt64Type || field.IndirectFieldType == wrapperInt64Type
+}
+
+// normalizeTimeFields applies each NilTime field's policy to the statement's model
+func normalizeTimeFields(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if !isTimeField(field) {
+			continue
+		}
+
+		policy, err := nihil.FieldTimePolicy(field.Tag, field.TagSettings["PRECISION"])
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if policy == (nihil.TimePolicy{}) {
+			continue
+		}
+
+		eachModel(db.Statement.ReflectValue, func(model reflect.Value) {
+			if n, ok := fieldNilTime(db, field, model); ok {
+				*n = n.Normalize(policy)
+			}
+		})
+	}
+}
+
+// eachModel calls fn for every struct in a statement's reflect value
+func eachModel(rv reflect.Value, fn func(reflect.Value)) {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				fn(elem)
+			}
+		}
+	case reflect.Struct:
+		fn(rv)
+	}
+}
+
+// fieldNilTime returns a pointer to field's nihil.NilTime in model, if settable
+func fieldNilTime(db *gorm.DB, field *schema.Field, model reflect.Value) (*nihil.NilTime, bool) {
+	fv := field.ReflectValueOf(db.Statement.Context, model)
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil, false
+		}
+		fv = fv.Elem()
+	}
+	if fv.Type() == wrapperTimeType {
+		fv = fv.Field(0)
+	}
+	if !fv.CanAddr() {
+		return nil, false
+	}
+	return fv.Addr().Interface().(*nihil.NilTime), true
+}
+
+// checkFields reports the nihil options of the statement's fields that the
+// plugin cannot apply
+func checkFields(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.IndirectFieldType == deletedAtType {
+			// Soft delete clauses write and compare native times themselves
+			if _, ok := nihil.TagOption(field.Tag, "storage"); ok {
+				db.AddError(fmt.Errorf("nihil: field %s.%s: DeletedAt does not support the storage option", field.Schema.Name, field.Name))
+				return
+			}
+			continue
+		}
+		if !isTimeField(field) {
+			continue
+		}
+
+		storage, err := nihil.FieldTimeStorage(field.Tag)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if _, ok := field.Serializer.(TimeSerializer); storage != nihil.StorageNative && !ok {
+			db.AddError(fmt.Errorf("nihil: field %s.%s: the storage option needs `gorm:\"serializer:nihiltime\"`", field.Schema.Name, field.Name))
+			return
+		}
+	}
+}
+
+// fillAutoTimes sets the zero auto time fields GORM cannot fill in itself
+// before create: NilTime fields with a TimePolicy and NilInt64 fields, for
+// which GORM sets a time.Time
+func fillAutoTimes(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+
+	now := stmt.DB.NowFunc()
+	for _, field := range stmt.Schema.Fields {
+		create, update := autoTimes(field)
+		unit := create
+		if unit == 0 {
+			unit = update
+		}
+		if unit == 0 {
+			continue
+		}
+		value, _, ok, err := autoTimeValue(field, unit, now)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		eachModel(stmt.ReflectValue, func(model reflect.Value) {
+			if _, zero := field.ValueOf(stmt.Context, model); zero {
+				db.AddError(field.Set(stmt.Context, model, value))
+			}
+		})
+	}
+}
+
+// assignmentsKey marks a SET clause added by prepareAssignments
+const assignmentsKey = "nihil:assignments"
+
+// prepareAssignments builds the SET clause of an update as GORM would, then
+// converts the values GORM cannot: NilTime values of fields with a storage
+// option given in a map, and the auto update times of NilTime fields with
+// a TimePolicy or storage option and of NilInt64 fields
+func prepareAssignments(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SQL.Len() > 0 {
+		return
+	}
+	if _, ok := stmt.Clauses["SET"]; ok {
+		return
+	}
+
+	storages := map[string]nihil.TimeStorage{}
+	autos := map[*schema.Field]schema.TimeType{}
+	for _, field := range stmt.Schema.Fields {
+		if isTimeField(field) {
+			storage, err := nihil.FieldTimeStorage(field.Tag)
+			if err != nil {
+				db.AddError(err)
+				return
+			}
+			if storage != nihil.StorageNative {
+				storages[field.DBName] = storage
+			}
+		}
+		if _, update := autoTimes(field); update > 0 && !stmt.SkipHooks {
+			autos[field] = update
+		}
+	}
+	if len(storages) == 0 && len(autos) == 0 {
+		return
+	}
+
+	set := callbacks.ConvertToAssignments(stmt)
+	if len(set) == 0 {
+		return // left to GORM, which skips the update
+	}
+	for i, assignment := range set {
+		if storage, ok := storages[assignment.Column.Name]; ok {
+			if n, ok := asNilTime(assignment.Value); ok {
+				set[i].Value = storage.Encode(n)
+			}
+		}
+	}
+
+	selected, _ := stmt.SelectAndOmitColumns(false, true)
+	given, _ := stmt.Dest.(map[string]any)
+	now := stmt.DB.NowFunc()
+	for field, unit := range autos {
+		if given[field.Name] != nil || given[field.DBName] != nil {
+			continue
+		}
+		if v, ok := selected[field.DBName]; ok && !v {
+			continue
+		}
+		value, column, ok, err := autoTimeValue(field, unit, now)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		assigned := false
+		for i := range set {
+			if set[i].Column.Name == field.DBName {
+				set[i].Value, assigned = column, true
+			}
+		}
+		if !assigned {
+			set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: column})
+		}
+		if stmt.ReflectValue.CanAddr() {
+			eachModel(stmt.ReflectValue, func(model reflect.Value) {
+				db.AddError(field.Set(stmt.Context, model, value))
+			})
+		}
+	}
+
+	stmt.AddClause(set)
+	stmt.Settings.Store(assignmentsKey, true)
+}
+
+// dropAssignments removes the SET clause added by prepareAssignments, as
+// GORM does with its own, so a reused statement builds a new one
+func dropAssignments(db *gorm.DB) {
+	if _, ok := db.Statement.Settings.LoadAndDelete(assignmentsKey); ok {
+		delete(db.Statement.Clauses, "SET")
+	}
+}
+
+// autoTimes returns the units of a field's auto create and update times.
+// Like int fields, NilInt64 fields named CreatedAt and UpdatedAt hold Unix
+// seconds unless tagged, though GORM only fills in tagged ones.
+func autoTimes(field *schema.Field) (create, update schema.TimeType) {
+	create, update = field.AutoCreateTime, field.AutoUpdateTime
+	if !isInt64Field(field) {
+		return create, update
+	}
+	if _, tagged := field.TagSettings["AUTOCREATETIME"]; !tagged && field.Name == "CreatedAt" {
+		create = schema.UnixSecond
+	}
+	if _, tagged := field.TagSettings["AUTOUPDATETIME"]; !tagged && field.Name == "UpdatedAt" {
+		update = schema.UnixSecond
+	}
+	return create, update
+}
+
+// autoTimeValue returns the value set on the model and the value written
+// to the column for an auto time field at now, or ok false when GORM's own
+// value is right
+func autoTimeValue(field *schema.Field, unit schema.TimeType, now time.Time) (value, column any, ok bool, err error) {
+	if isInt64Field(field) {
+		switch unit {
+		case schema.UnixNanosecond:
+			value = now.UnixNano()
+		case schema.UnixMillisecond:
+			value = now.UnixMilli()
+		default:
+			value = now.Unix()
+		}
+		return value, value, true, nil
+	}
+	if !isTimeField(field) {
+		return nil, nil, false, nil
+	}
+
+	policy, err := nihil.FieldTimePolicy(field.Tag, field.TagSettings["PRECISION"])
+	if err != nil {
+		return nil, nil, false, err
+	}
+	storage, err := nihil.FieldTimeStorage(field.Tag)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if policy == (nihil.TimePolicy{}) && storage == nihil.StorageNative {
+		return nil, nil, false, nil
+	}
+	t := policy.Apply(now)
+	return t, storage.Encode(nihil.Time(t)), true, nil
+}
+
+// asNilTime returns the nihil.NilTime held by a field value
+func asNilTime(value any) (nihil.NilTime, bool) {
+	switch n := value.(type) {
+	case nihil.NilTime:
+		return n, true
+	case *nihil.NilTime:
+		if n != nil {
+			return *n, true
+		}
+	case NilTime:
+		return n.NilTime, true
+	case *NilTime:
+		if n != nil {
+			return n.NilTime, true
+		}
+	}
+	return nihil.NilTime{}, false
+}
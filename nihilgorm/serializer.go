@@ -0,0 +1,207 @@
+package nihilgorm
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("nihiljson", JSONSerializer{})
+	schema.RegisterSerializer("nihilgob", GobSerializer{})
+	schema.RegisterSerializer("nihiltime", TimeSerializer{})
+}
+
+// JSONSerializer stores a struct, map or slice holding nihil values as one
+// JSON column, registered as `serializer:nihiljson`:
+//
+//	type Profile struct {
+//		Nickname nihil.NilString `json:"nickname"`
+//		Birthday nihil.NilTime   `json:"birthday"`
+//	}
+//
+//	type User struct {
+//		ID      uint
+//		Profile *Profile `gorm:"serializer:nihiljson"`
+//	}
+//
+// Unlike `serializer:json`, a nil pointer, map or slice, or a null nihil
+// value, is stored as SQL NULL rather than the text "null" or "", and NULL
+// is read back as the zero value. Null nihil values inside the document
+// are stored as JSON null. Decoding errors are DecodeErrors with the JSON
+// path of the failing value.
+type JSONSerializer struct{}
+
+func (JSONSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	if data, err := serializedBytes(field, dbValue); err != nil {
+		return err
+	} else if len(data) > 0 {
+		if err := nihil.Unmarshal(data, fieldValue.Interface()); err != nil {
+			return fmt.Errorf("nihil: column %s: %w", field.DBName, err)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+func (JSONSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	if isNullDocument(fieldValue) {
+		return nullDocument(field, "null"), nil
+	}
+	data, err := json.Marshal(fieldValue)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nullDocument(field, "null"), nil
+	}
+	return string(data), nil
+}
+
+// GobSerializer stores a value holding nihil values as one gob encoded
+// column, registered as `serializer:nihilgob`. Nil and null values are
+// stored as SQL NULL, which gob itself cannot encode.
+type GobSerializer struct{}
+
+func (GobSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	if data, err := serializedBytes(field, dbValue); err != nil {
+		return err
+	} else if len(data) > 0 {
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(fieldValue.Interface()); err != nil {
+			return fmt.Errorf("nihil: column %s: %w", field.DBName, err)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+func (GobSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	if isNullDocument(fieldValue) {
+		return nullDocument(field, []byte{}), nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(fieldValue); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// TimeSerializer stores a NilTime with the mode of its field's
+// `nihil:"storage=..."` option, registered as `serializer:nihiltime`:
+//
+//	type Metric struct {
+//		ID         uint
+//		RecordedAt nihilgorm.NilTime `gorm:"serializer:nihiltime" nihil:"storage=unixmilli"`
+//	}
+//
+// GORM applies it wherever it reads or writes the field, including
+// Preload, associations and rows scanned into a struct other than the
+// model.
+type TimeSerializer struct{}
+
+func (TimeSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	storage, err := nihil.FieldTimeStorage(field.Tag)
+	if err != nil {
+		return err
+	}
+	n, err := storage.Decode(dbValue)
+	if err != nil {
+		return fmt.Errorf("nihil: column %s: %w", field.DBName, err)
+	}
+
+	var fieldValue reflect.Value
+	switch field.IndirectFieldType {
+	case nilTimeType:
+		fieldValue = reflect.ValueOf(n)
+	case wrapperTimeType:
+		fieldValue = reflect.ValueOf(NilTime{n})
+	default:
+		return fmt.Errorf("nihil: field %s.%s: serializer nihiltime needs a NilTime, got %s", field.Schema.Name, field.Name, field.FieldType)
+	}
+	if field.FieldType.Kind() == reflect.Pointer {
+		if dbValue == nil {
+			fieldValue = reflect.Zero(field.FieldType)
+		} else {
+			ptr := reflect.New(field.IndirectFieldType)
+			ptr.Elem().Set(fieldValue)
+			fieldValue = ptr
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+func (TimeSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	storage, err := nihil.FieldTimeStorage(field.Tag)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := asNilTime(fieldValue)
+	return storage.Encode(n), nil
+}
+
+// serializedBytes returns the bytes of a serialized column value
+func serializedBytes(field *schema.Field, dbValue any) ([]byte, error) {
+	switch v := dbValue.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, &nihil.ScanError{
+		Type:   field.FieldType.String(),
+		Source: reflect.TypeOf(dbValue),
+		Value:  dbValue,
+		Column: field.DBName,
+		Reason: "want serialized text or bytes",
+	}
+}
+
+// isNullDocument reports whether v is stored as NULL: nil, a nil pointer,
+// map, slice or interface, or a null nihil value
+func isNullDocument(v any) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	case reflect.Struct:
+		if valuer, ok := rv.Interface().(driver.Valuer); ok {
+			value, err := valuer.Value()
+			return err == nil && value == nil
+		}
+	}
+	return false
+}
+
+// nullDocument returns the value stored for a null document: SQL NULL, or
+// the serializer's empty document in NOT NULL columns
+func nullDocument(field *schema.Field, empty any) any {
+	if _, notNull := field.TagSettings["NOT NULL"]; notNull {
+		return empty
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package nihilgorm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+)
+
+type serializedProfile struct {
+	Nickname NilString `json:"nickname"`
+	Age      NilInt32  `json:"age"`
+	Birthday NilTime   `json:"birthday"`
+}
+
+type GormSerializerModel struct {
+	ID       uint                 `gorm:"primarykey"`
+	Profile  *serializedProfile   `gorm:"serializer:nihiljson"`
+	Tags     map[string]NilString `gorm:"serializer:nihiljson"`
+	Scores   []NilFloat64         `gorm:"serializer:nihiljson"`
+	Nickname NilString            `gorm:"serializer:nihiljson"`
+	Blob     *serializedProfile   `gorm:"serializer:nihilgob"`
+	Counts   map[string]NilInt32  `gorm:"serializer:nihiljson;not null"`
+}
+
+func TestGORM_Serializer(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&GormSerializerModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	profile := &serializedProfile{Nickname: String("ally"), Age: Int32Nil(), Birthday: Time(birthday)}
+	model := GormSerializerModel{
+		Profile:  profile,
+		Tags:     map[string]NilString{"team": String("core"), "role": StringNil()},
+		Scores:   []NilFloat64{Float64(1.5), Float64Nil()},
+		Nickname: String("al"),
+		Blob:     profile,
+		Counts:   map[string]NilInt32{"a": Int32(1)},
+	}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	var raw struct{ Profile, Tags, Scores, Nickname string }
+	if err := db.Raw("SELECT profile, tags, scores, nickname FROM gorm_serializer_models WHERE id = ?", model.ID).
+		Row().Scan(&raw.Profile, &raw.Tags, &raw.Scores, &raw.Nickname); err != nil {
+		t.Fatalf("Failed to read raw columns: %v", err)
+	}
+	expectedRaw := map[string][2]string{
+		"profile":  {`{"nickname":"ally","age":null,"birthday":"1990-05-17T00:00:00Z"}`, raw.Profile},
+		"tags":     {`{"role":null,"team":"core"}`, raw.Tags},
+		"scores":   {`[1.5,null]`, raw.Scores},
+		"nickname": {`"al"`, raw.Nickname},
+	}
+	for column, values := range expectedRaw {
+		if values[0] != values[1] {
+			t.Errorf("Column %s: expected %s, got %s", column, values[0], values[1])
+		}
+	}
+
+	var retrieved GormSerializerModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if *retrieved.Profile != *profile {
+		t.Errorf("Profile mismatch: expected %+v, got %+v", *profile, *retrieved.Profile)
+	}
+	if *retrieved.Blob != *profile {
+		t.Errorf("Blob mismatch: expected %+v, got %+v", *profile, *retrieved.Blob)
+	}
+	if len(retrieved.Tags) != 2 || retrieved.Tags["team"] != String("core") || retrieved.Tags["role"].Valid {
+		t.Errorf("Tags mismatch: %+v", retrieved.Tags)
+	}
+	if len(retrieved.Scores) != 2 || retrieved.Scores[0] != Float64(1.5) || retrieved.Scores[1].Valid {
+		t.Errorf("Scores mismatch: %+v", retrieved.Scores)
+	}
+	if retrieved.Nickname != String("al") {
+		t.Errorf("Nickname mismatch: %+v", retrieved.Nickname)
+	}
+}
+
+func TestGORM_SerializerNulls(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&GormSerializerModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	model := GormSerializerModel{Nickname: StringNil()}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	var raw struct{ Profile, Tags, Scores, Nickname, Blob, Counts any }
+	if err := db.Raw("SELECT profile, tags, scores, nickname, blob, counts FROM gorm_serializer_models WHERE id = ?", model.ID).
+		Row().Scan(&raw.Profile, &raw.Tags, &raw.Scores, &raw.Nickname, &raw.Blob, &raw.Counts); err != nil {
+		t.Fatalf("Failed to read raw columns: %v", err)
+	}
+	for column, value := range map[string]any{
+		"profile": raw.Profile, "tags": raw.Tags, "scores": raw.Scores, "nickname": raw.Nickname, "blob": raw.Blob,
+	} {
+		if value != nil {
+			t.Errorf("Column %s: expected NULL, got %v", column, value)
+		}
+	}
+	if raw.Counts != "null" {
+		t.Errorf("Expected NOT NULL column to hold JSON null, got %v", raw.Counts)
+	}
+
+	retrieved := GormSerializerModel{Profile: &serializedProfile{}, Nickname: String("stale")}
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.Profile != nil || retrieved.Tags != nil || retrieved.Scores != nil ||
+		retrieved.Nickname.Valid || retrieved.Blob != nil || retrieved.Counts != nil {
+		t.Errorf("Expected zero values, got %+v", retrieved)
+	}
+}
+
+func TestGORM_SerializerDecodeError(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&GormSerializerModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO gorm_serializer_models (profile, counts) VALUES (?, ?)`,
+		`{"nickname":"ally","age":"old"}`, "null").Error; err != nil {
+		t.Fatalf("Failed to insert record: %v", err)
+	}
+
+	var retrieved GormSerializerModel
+	err := db.First(&retrieved).Error
+	var de *nihil.DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected DecodeError, got %v", err)
+	}
+	if de.Path != "age" || de.Type != "NilInt32" {
+		t.Errorf("Expected NilInt32 error at age, got %+v", de)
+	}
+}
@@ -0,0 +1,46 @@
+package nihilgorm
+
+import (
+	"database/sql"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DeletedAt is a NilTime that enables GORM soft delete, like gorm.DeletedAt
+// but marshaling to null or the timestamp in JSON
+//
+//	type User struct {
+//		ID        uint
+//		DeletedAt nihilgorm.DeletedAt `gorm:"index" json:"deleted_at"`
+//	}
+//
+// Delete sets the column to the current time instead of removing the row,
+// queries and updates skip deleted rows, and Unscoped disables both.
+//
+// The column always holds a native time, as GORM's soft delete clauses
+// write and compare one: the `nihil:"storage=..."` option is not supported,
+// and Plugin reports it as an error.
+type DeletedAt struct{ nihil.NilTime }
+
+func (DeletedAt) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	native := *field
+	native.Tag = "" // no storage option
+	return ColumnType(db, &native, nihil.KindTime, nil)
+}
+
+// Soft delete uses GORM's own clauses, with NULL marking rows not deleted
+
+func (DeletedAt) QueryClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{gorm.SoftDeleteQueryClause{Field: f, ZeroValue: sql.NullString{}}}
+}
+
+func (DeletedAt) UpdateClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{gorm.SoftDeleteUpdateClause{Field: f, ZeroValue: sql.NullString{}}}
+}
+
+func (DeletedAt) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{gorm.SoftDeleteDeleteClause{Field: f, ZeroValue: sql.NullString{}}}
+}
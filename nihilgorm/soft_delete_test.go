@@ -0,0 +1,153 @@
+package nihilgorm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type GormSoftDeleteModel struct {
+	ID        uint      `gorm:"primarykey"`
+	Name      NilString `json:"name"`
+	DeletedAt DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+func setupSoftDeleteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&GormSoftDeleteModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+func TestGORM_SoftDelete(t *testing.T) {
+	db := setupSoftDeleteDB(t)
+
+	models := []GormSoftDeleteModel{{Name: String("Alice")}, {Name: String("Bob")}}
+	if err := db.Create(&models).Error; err != nil {
+		t.Fatalf("Failed to create records: %v", err)
+	}
+
+	// Delete becomes an UPDATE of deleted_at
+	if err := db.Delete(&models[0]).Error; err != nil {
+		t.Fatalf("Failed to delete record: %v", err)
+	}
+	if !models[0].DeletedAt.Valid || models[0].DeletedAt.Time.IsZero() {
+		t.Errorf("Expected DeletedAt to be set on the model, got %+v", models[0].DeletedAt)
+	}
+
+	var visible []GormSoftDeleteModel
+	if err := db.Find(&visible).Error; err != nil {
+		t.Fatalf("Failed to query records: %v", err)
+	}
+	if len(visible) != 1 || visible[0].Name.String != "Bob" {
+		t.Errorf("Expected only Bob to be visible, got %+v", visible)
+	}
+
+	// Updates skip deleted rows
+	if err := db.Model(&GormSoftDeleteModel{}).Where("1 = 1").Update("name", "Renamed").Error; err != nil {
+		t.Fatalf("Failed to update records: %v", err)
+	}
+
+	var all []GormSoftDeleteModel
+	if err := db.Unscoped().Order("id").Find(&all).Error; err != nil {
+		t.Fatalf("Failed to query unscoped records: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 rows with Unscoped, got %d", len(all))
+	}
+	if !all[0].DeletedAt.Valid || all[0].Name.String != "Alice" {
+		t.Errorf("Expected deleted, unchanged Alice, got %+v", all[0])
+	}
+	if all[1].DeletedAt.Valid || all[1].Name.String != "Renamed" {
+		t.Errorf("Expected live, renamed Bob, got %+v", all[1])
+	}
+
+	// Unscoped delete removes the row
+	if err := db.Unscoped().Delete(&models[0]).Error; err != nil {
+		t.Fatalf("Failed to hard delete record: %v", err)
+	}
+	var count int64
+	db.Unscoped().Model(&GormSoftDeleteModel{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 row after hard delete, got %d", count)
+	}
+}
+
+func TestDeletedAt_JSON(t *testing.T) {
+	type record struct {
+		DeletedAt DeletedAt `json:"deleted_at"`
+	}
+	deletedAt := time.Date(2025, 7, 31, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    DeletedAt
+		expected string
+	}{
+		{"null", DeletedAt{}, `{"deleted_at":null}`},
+		{"deleted", DeletedAt{nihil.Time(deletedAt)}, `{"deleted_at":"2025-07-31T10:00:00Z"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(record{DeletedAt: tt.value})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+
+			var decoded record
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded.DeletedAt.Valid != tt.value.Valid || !decoded.DeletedAt.Time.Equal(tt.value.Time) {
+				t.Errorf("Expected %+v, got %+v", tt.value, decoded.DeletedAt)
+			}
+		})
+	}
+
+	var decoded DeletedAt
+	if err := json.Unmarshal([]byte(`"yesterday"`), &decoded); err == nil {
+		t.Error("Expected error for invalid timestamp")
+	}
+}
+
+func TestDeletedAt_StorageOption(t *testing.T) {
+	type GormSoftDeleteUnixModel struct {
+		ID        uint      `gorm:"primarykey"`
+		DeletedAt DeletedAt `nihil:"storage=unixmilli"`
+	}
+
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&GormSoftDeleteUnixModel{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(&GormSoftDeleteUnixModel{})
+	if err != nil {
+		t.Fatalf("Failed to read column types: %v", err)
+	}
+	for _, ct := range columnTypes {
+		if ct.Name() == "deleted_at" && ct.DatabaseTypeName() != "DATETIME" {
+			t.Errorf("Expected a native DATETIME column, got %s", ct.DatabaseTypeName())
+		}
+	}
+
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	err = db.Create(&GormSoftDeleteUnixModel{}).Error
+	if err == nil || !strings.Contains(err.Error(), "DeletedAt does not support the storage option") {
+		t.Errorf("Expected the storage option to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package nihilgorm
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Wrapper types embed the nihil type of the same name, so Scan, Value,
+// JSON marshaling and the Valid and value fields are promoted unchanged,
+// and add GormDBDataType, which maps them to the column type of the
+// connection's dialect in the nihil dialect registry.
+
+// NilByte is a nihil.NilByte with dialect specific GORM column types
+type NilByte struct{ nihil.NilByte }
+
+// NilBool is a nihil.NilBool with dialect specific GORM column types
+type NilBool struct{ nihil.NilBool }
+
+// NilFloat64 is a nihil.NilFloat64 with dialect specific GORM column types
+type NilFloat64 struct{ nihil.NilFloat64 }
+
+// NilInt16 is a nihil.NilInt16 with dialect specific GORM column types
+type NilInt16 struct{ nihil.NilInt16 }
+
+// NilInt32 is a nihil.NilInt32 with dialect specific GORM column types
+type NilInt32 struct{ nihil.NilInt32 }
+
+// NilInt64 is a nihil.NilInt64 with dialect specific GORM column types
+type NilInt64 struct{ nihil.NilInt64 }
+
+// NilString is a nihil.NilString with dialect specific GORM column types
+type NilString struct{ nihil.NilString }
+
+// NilTime is a nihil.NilTime with dialect specific GORM column types
+type NilTime struct{ nihil.NilTime }
+
+// NilEnum is a nihil.NilEnum with dialect specific GORM column types
+type NilEnum[E ~string] struct{ nihil.NilEnum[E] }
+
+// Byte creates a valid NilByte with the given value
+func Byte(b byte) NilByte { return NilByte{nihil.Byte(b)} }
+
+// ByteNil creates an invalid (null) NilByte
+func ByteNil() NilByte { return NilByte{nihil.ByteNil()} }
+
+// Bool creates a valid NilBool with the given value
+func Bool(b bool) NilBool { return NilBool{nihil.Bool(b)} }
+
+// BoolNil creates an invalid (null) NilBool
+func BoolNil() NilBool { return NilBool{nihil.BoolNil()} }
+
+// Float64 creates a valid NilFloat64 with the given value
+func Float64(f float64) NilFloat64 { return NilFloat64{nihil.Float64(f)} }
+
+// Float64Nil creates an invalid (null) NilFloat64
+func Float64Nil() NilFloat64 { return NilFloat64{nihil.Float64Nil()} }
+
+// Int16 creates a valid NilInt16 with the given value
+func Int16(i int16) NilInt16 { return NilInt16{nihil.Int16(i)} }
+
+// Int16Nil creates an invalid (null) NilInt16
+func Int16Nil() NilInt16 { return NilInt16{nihil.Int16Nil()} }
+
+// Int32 creates a valid NilInt32 with the given value
+func Int32(i int32) NilInt32 { return NilInt32{nihil.Int32(i)} }
+
+// Int32Nil creates an invalid (null) NilInt32
+func Int32Nil() NilInt32 { return NilInt32{nihil.Int32Nil()} }
+
+// Int64 creates a valid NilInt64 with the given value
+func Int64(i int64) NilInt64 { return NilInt64{nihil.Int64(i)} }
+
+// Int64Nil creates an invalid (null) NilInt64
+func Int64Nil() NilInt64 { return NilInt64{nihil.Int64Nil()} }
+
+// String creates a valid NilString with the given value
+func String(s string) NilString { return NilString{nihil.String(s)} }
+
+// StringNil creates an invalid (null) NilString
+func StringNil() NilString { return NilString{nihil.StringNil()} }
+
+// Time creates a valid NilTime with the given value
+func Time(t time.Time) NilTime { return NilTime{nihil.Time(t)} }
+
+// TimeNil creates an invalid (null) NilTime
+func TimeNil() NilTime { return NilTime{nihil.TimeNil()} }
+
+// Enum creates a valid NilEnum with the given value
+func Enum[E ~string](e E) NilEnum[E] { return NilEnum[E]{nihil.Enum(e)} }
+
+// EnumNil creates an invalid (null) NilEnum
+func EnumNil[E ~string]() NilEnum[E] { return NilEnum[E]{nihil.EnumNil[E]()} }
+
+// GormDBDataTypeInterface implementations
+
+func (NilByte) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindByte, nil)
+}
+
+func (NilBool) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindBool, nil)
+}
+
+func (NilFloat64) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindFloat64, nil)
+}
+
+func (NilInt16) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindInt16, nil)
+}
+
+func (NilInt32) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindInt32, nil)
+}
+
+func (NilInt64) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindInt64, nil)
+}
+
+func (NilString) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindString, nil)
+}
+
+func (NilTime) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return ColumnType(db, field, nihil.KindTime, nil)
+}
+
+func (NilEnum[E]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	registered := nihil.EnumValues[E]()
+	values := make([]string, len(registered))
+	for i, v := range registered {
+		values[i] = string(v)
+	}
+	return ColumnType(db, field, nihil.KindEnum, values)
+}
+
+// ColumnType looks up a field's column type in the nihil dialect registry
+// for the connection's dialect, quoting identifiers the way its dialector
+// does. Use it to implement GormDBDataType on your own wrapper types.
+func ColumnType(db *gorm.DB, field *schema.Field, kind nihil.Kind, values []string) string {
+	return nihil.ColumnType(db.Name(), kind, nihil.Column{
+		Name:     field.DBName,
+		Settings: field.TagSettings,
+		Tag:      field.Tag,
+		Values:   values,
+		Quote: func(name string) string {
+			var b strings.Builder
+			db.Dialector.QuoteTo(&b, name)
+			return b.String()
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package nihilgorm
+
+import (
+	"testing"
+
+	"github.com/mrrizkin/nihil"
+)
+
+type gormTestPatch struct {
+	Name  nihil.Optional[NilString]
+	Score nihil.Optional[NilFloat64]
+	Level *NilByte
+}
+
+func TestGORM_UpdateMap(t *testing.T) {
+	db := setupTestDB(t)
+	model := GormTestModel{
+		Name:  NilString{nihil.String("Alice")},
+		Age:   NilInt32{nihil.Int32(30)},
+		Score: NilFloat64{nihil.Float64(1.5)},
+		Level: NilByte{nihil.Byte(3)},
+	}
+	if err := db.Create(&model).Error; err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	// Name is set to null; Score and Level are left untouched
+	set, err := nihil.UpdateMap(gormTestPatch{Name: nihil.Set(NilString{nihil.StringNil()})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := db.Model(&model).Updates(set).Error; err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+
+	var retrieved GormTestModel
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.Name.Valid {
+		t.Errorf("Expected name to be NULL, got %+v", retrieved.Name)
+	}
+	if retrieved.Score.Float64 != 1.5 || retrieved.Level.Byte != 3 {
+		t.Errorf("Expected score and level to be untouched, got %v and %v", retrieved.Score, retrieved.Level)
+	}
+
+	// The same patch through database/sql style SQL
+	level := NilByte{nihil.ByteNil()}
+	setSQL, args, err := nihil.UpdateSQL("sqlite", gormTestPatch{Score: nihil.Set(NilFloat64{nihil.Float64(2.5)}), Level: &level})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := db.Exec("UPDATE gorm_test_models SET "+setSQL+" WHERE id = ?", append(args, model.ID)...).Error; err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+	retrieved = GormTestModel{}
+	if err := db.First(&retrieved, model.ID).Error; err != nil {
+		t.Fatalf("Failed to retrieve record: %v", err)
+	}
+	if retrieved.Score.Float64 != 2.5 || retrieved.Level.Valid || retrieved.Age.Int32 != 30 {
+		t.Errorf("Unexpected record after SQL update: %+v", retrieved)
+	}
+}
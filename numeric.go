@@ -34,7 +34,7 @@ func (n *NilFloat64) isValid() bool          { return n.Valid }
 func (n *NilFloat64) getValue() float64      { return n.Float64 }
 func (n *NilFloat64) setValid(valid bool)    { n.Valid = valid }
 func (n *NilFloat64) setValue(value float64) { n.Float64 = value }
-func (n *NilFloat64) scan(value any) error   { return (*sql.NullFloat64)(n).Scan(value) }
+func (n *NilFloat64) scan(value any) error   { return scanNullable(n, value, convertFloat64) }
 func (n *NilFloat64) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
@@ -54,7 +54,7 @@ func (n *NilInt16) isValid() bool        { return n.Valid }
 func (n *NilInt16) getValue() int16      { return n.Int16 }
 func (n *NilInt16) setValid(valid bool)  { n.Valid = valid }
 func (n *NilInt16) setValue(value int16) { n.Int16 = value }
-func (n *NilInt16) scan(value any) error { return (*sql.NullInt16)(n).Scan(value) }
+func (n *NilInt16) scan(value any) error { return scanNullable(n, value, convertInt16) }
 func (n *NilInt16) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
@@ -73,7 +73,7 @@ func (n *NilInt32) isValid() bool        { return n.Valid }
 func (n *NilInt32) getValue() int32      { return n.Int32 }
 func (n *NilInt32) setValid(valid bool)  { n.Valid = valid }
 func (n *NilInt32) setValue(value int32) { n.Int32 = value }
-func (n *NilInt32) scan(value any) error { return (*sql.NullInt32)(n).Scan(value) }
+func (n *NilInt32) scan(value any) error { return scanNullable(n, value, convertInt32) }
 func (n *NilInt32) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
@@ -92,7 +92,7 @@ func (n *NilInt64) isValid() bool        { return n.Valid }
 func (n *NilInt64) getValue() int64      { return n.Int64 }
 func (n *NilInt64) setValid(valid bool)  { n.Valid = valid }
 func (n *NilInt64) setValue(value int64) { n.Int64 = value }
-func (n *NilInt64) scan(value any) error { return (*sql.NullInt64)(n).Scan(value) }
+func (n *NilInt64) scan(value any) error { return scanNullable(n, value, convertInt64) }
 func (n *NilInt64) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
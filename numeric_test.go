@@ -112,3 +112,43 @@ func TestAllNumericConstructors(t *testing.T) {
 		t.Error("Int64Nil constructor failed")
 	}
 }
+
+func TestNumeric_Scan(t *testing.T) {
+	var i16 NilInt16
+	if err := i16.Scan([]byte("-12")); err != nil || !i16.Valid || i16.Int16 != -12 {
+		t.Errorf("Int16 scan from []byte failed: %v %+v", err, i16)
+	}
+	if err := i16.Scan(int64(40000)); err == nil {
+		t.Error("Expected out of range error for Int16")
+	}
+
+	var i32 NilInt32
+	if err := i32.Scan("123"); err != nil || !i32.Valid || i32.Int32 != 123 {
+		t.Errorf("Int32 scan from string failed: %v %+v", err, i32)
+	}
+	if err := i32.Scan(float64(5)); err != nil || i32.Int32 != 5 {
+		t.Errorf("Int32 scan from integral float failed: %v %+v", err, i32)
+	}
+	if err := i32.Scan(nil); err != nil || i32.Valid || i32.Int32 != 0 {
+		t.Errorf("Int32 scan from nil failed: %v %+v", err, i32)
+	}
+
+	var i64 NilInt64
+	if err := i64.Scan([]byte("9007199254740993")); err != nil || i64.Int64 != 9007199254740993 {
+		t.Errorf("Int64 scan from []byte failed: %v %+v", err, i64)
+	}
+	if err := i64.Scan(1.5); err == nil {
+		t.Error("Expected error scanning fractional float into Int64")
+	}
+
+	var f64 NilFloat64
+	if err := f64.Scan([]byte("95.5")); err != nil || !f64.Valid || f64.Float64 != 95.5 {
+		t.Errorf("Float64 scan from []byte failed: %v %+v", err, f64)
+	}
+	if err := f64.Scan(int64(3)); err != nil || f64.Float64 != 3 {
+		t.Errorf("Float64 scan from int64 failed: %v %+v", err, f64)
+	}
+	if err := f64.Scan("not a number"); err == nil {
+		t.Error("Expected error scanning text into Float64")
+	}
+}
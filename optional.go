@@ -0,0 +1,52 @@
+package nihil
+
+import "encoding/json"
+
+// Optional is a tri-state field for partial updates: unset, set to null, or
+// set to a value. Use it with a nihil type to tell a missing JSON key from
+// an explicit null:
+//
+//	type UserPatch struct {
+//		Email      nihil.Optional[nihil.NilString] `json:"email,omitzero"`
+//		MiddleName nihil.Optional[nihil.NilString] `json:"middle_name,omitzero"`
+//	}
+//
+// Decoding {"middle_name": null} sets MiddleName to a null NilString and
+// leaves Email unset. Unset fields are skipped by UpdateMap and UpdateSQL,
+// and omitted by json.Marshal with the omitzero option.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Set creates an Optional holding v
+func Set[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// IsZero reports whether o is unset, for the omitzero JSON option
+func (o Optional[T]) IsZero() bool { return !o.Set }
+
+// Get returns the value and whether it is set
+func (o Optional[T]) Get() (T, bool) { return o.Value, o.Set }
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON marks o as set and decodes the value, including null,
+// into Value
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	var value T
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*o = Optional[T]{Value: value, Set: true}
+	return nil
+}
+
+// optionalValue returns the value of o when it is set, for reflection
+func (o Optional[T]) optionalValue() (any, bool) { return o.Value, o.Set }
+
+// optional is the non-generic part of Optional, used for reflection
+type optional interface {
+	optionalValue() (any, bool)
+}
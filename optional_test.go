@@ -0,0 +1,54 @@
+package nihil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type optionalPatch struct {
+	Email      Optional[NilString] `json:"email,omitzero"`
+	MiddleName Optional[NilString] `json:"middle_name,omitzero"`
+	Age        Optional[NilInt32]  `json:"age,omitzero"`
+}
+
+func TestOptional_JSONUnmarshaling(t *testing.T) {
+	var patch optionalPatch
+	if err := json.Unmarshal([]byte(`{"middle_name": null, "age": 30}`), &patch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if patch.Email.Set {
+		t.Errorf("Expected missing email to be unset, got %+v", patch.Email)
+	}
+	if !patch.MiddleName.Set || patch.MiddleName.Value.Valid {
+		t.Errorf("Expected middle_name to be set to null, got %+v", patch.MiddleName)
+	}
+	if age, ok := patch.Age.Get(); !ok || age != Int32(30) {
+		t.Errorf("Expected age to be set to 30, got %+v", patch.Age)
+	}
+}
+
+func TestOptional_JSONMarshaling(t *testing.T) {
+	patch := optionalPatch{MiddleName: Set(StringNil()), Age: Set(Int32(30))}
+	b, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"middle_name":null,"age":30}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+}
+
+func TestOptional_DecodeErrorPath(t *testing.T) {
+	var patch optionalPatch
+	err := Unmarshal([]byte(`{"age": "thirty"}`), &patch)
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected a DecodeError, got %v", err)
+	}
+	if de.Path != "age" {
+		t.Errorf("Expected path age, got %q", de.Path)
+	}
+}
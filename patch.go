@@ -0,0 +1,213 @@
+package nihil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to the struct dst
+// points to
+//
+// Keys are matched to fields by their JSON names. A null member clears the
+// field: nihil fields become null, pointers, slices, maps and interfaces
+// become nil, and other fields are reset to their zero value. Missing keys
+// leave fields untouched, and object members merge into nested structs and
+// string-keyed maps instead of replacing them. Other values replace the
+// field as json.Unmarshal would decode them. Unknown keys are ignored.
+//
+// dst is left unchanged when the patch fails to apply; decoding failures on
+// nihil fields are reported as a *DecodeError with Path set.
+func ApplyMergePatch(dst any, patch []byte) error {
+	_, err := ApplyMergePatchChanges(dst, patch)
+	return err
+}
+
+// ApplyMergePatchChanges is ApplyMergePatch that also returns the JSON
+// paths of the fields whose value changed, such as "name" or
+// "address.city", in patch order
+func ApplyMergePatchChanges(dst any, patch []byte) ([]string, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nihil: ApplyMergePatch expects a non-nil pointer to a struct, got %T", dst)
+	}
+	if !isJSONObject(patch) {
+		return nil, errors.New("nihil: merge patch must be a JSON object")
+	}
+
+	// Merge into a copy so a failing patch leaves dst untouched; nested
+	// pointers and maps are copied before they are modified
+	merged := reflect.New(rv.Elem().Type()).Elem()
+	merged.Set(rv.Elem())
+
+	var changed []string
+	if err := mergeStruct(merged, patch, "", &changed); err != nil {
+		return nil, err
+	}
+	rv.Elem().Set(merged)
+	return changed, nil
+}
+
+// mergeStruct merges the members of a JSON object into the struct v
+func mergeStruct(v reflect.Value, patch []byte, path string, changed *[]string) error {
+	fields := jsonFields(v.Type())
+	for _, member := range objectMembers(patch) {
+		f, ok := lookupJSONField(fields, member.key)
+		if !ok {
+			continue
+		}
+		fv, err := fieldForPatch(v, f.Index)
+		if err != nil {
+			return fmt.Errorf("nihil: field %q: %w", joinPath(path, f.Name), err)
+		}
+		if err := mergeValue(fv, member.value, joinPath(path, f.Name), changed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldForPatch returns the field at index, copying the embedded struct
+// pointers on the way so the original values are not modified
+func fieldForPatch(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, errors.New("cannot set embedded pointer to unexported struct")
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			} else {
+				copyPointer(v)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// mergeValue applies one patch member to v
+func mergeValue(v reflect.Value, value []byte, path string, changed *[]string) error {
+	if isJSONNull(value) {
+		if !sameJSON(v, reflect.Zero(v.Type())) {
+			*changed = append(*changed, path)
+		}
+		v.SetZero()
+		return nil
+	}
+
+	if isJSONObject(value) {
+		switch {
+		case isMergeableStruct(v.Type()):
+			return mergeStruct(v, value, path, changed)
+		case v.Kind() == reflect.Pointer && isMergeableStruct(v.Type().Elem()):
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			} else {
+				copyPointer(v)
+			}
+			return mergeStruct(v.Elem(), value, path, changed)
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			return mergeMap(v, value, path, changed)
+		}
+	}
+
+	replaced := reflect.New(v.Type())
+	if err := json.Unmarshal(value, replaced.Interface()); err != nil {
+		if located := locateDecodeError(v.Type(), value, path); located != nil {
+			return located
+		}
+		return fmt.Errorf("nihil: field %q: %w", path, err)
+	}
+	if !sameJSON(v, replaced.Elem()) {
+		v.Set(replaced.Elem())
+		*changed = append(*changed, path)
+	}
+	return nil
+}
+
+// mergeMap merges the members of a JSON object into a string-keyed map;
+// null members delete their key
+func mergeMap(v reflect.Value, patch []byte, path string, changed *[]string) error {
+	members := objectMembers(patch)
+	if len(members) == 0 {
+		return nil
+	}
+	merged := reflect.MakeMap(v.Type())
+	if !v.IsNil() {
+		iter := v.MapRange()
+		for iter.Next() {
+			merged.SetMapIndex(iter.Key(), iter.Value())
+		}
+	}
+
+	for _, member := range members {
+		key := reflect.ValueOf(member.key).Convert(v.Type().Key())
+		memberPath := joinPath(path, member.key)
+		old := merged.MapIndex(key)
+
+		if isJSONNull(member.value) {
+			if old.IsValid() {
+				merged.SetMapIndex(key, reflect.Value{})
+				*changed = append(*changed, memberPath)
+			}
+			continue
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old.IsValid() {
+			elem.Set(old)
+			if err := mergeValue(elem, member.value, memberPath, changed); err != nil {
+				return err
+			}
+		} else {
+			// A new key is one change, whatever it holds
+			if err := mergeValue(elem, member.value, memberPath, new([]string)); err != nil {
+				return err
+			}
+			*changed = append(*changed, memberPath)
+		}
+		merged.SetMapIndex(key, elem)
+	}
+
+	v.Set(merged)
+	return nil
+}
+
+// isMergeableStruct reports whether a JSON object merges into t field by
+// field, rather than replacing it through its own decoding
+func isMergeableStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isNullable(t) &&
+		!reflect.PointerTo(t).Implements(reflect.TypeFor[json.Unmarshaler]())
+}
+
+// copyPointer points v at a copy of the value it points to
+func copyPointer(v reflect.Value) {
+	copied := reflect.New(v.Type().Elem())
+	copied.Elem().Set(v.Elem())
+	v.Set(copied)
+}
+
+// sameJSON reports whether a and b encode to the same JSON
+func sameJSON(a, b reflect.Value) bool {
+	aj, aerr := json.Marshal(a.Interface())
+	bj, berr := json.Marshal(b.Interface())
+	if aerr != nil || berr != nil {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	return bytes.Equal(aj, bj)
+}
+
+// isJSONNull reports whether data is the JSON null literal
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// isJSONObject reports whether data is a valid JSON object
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{' && json.Valid(data)
+}
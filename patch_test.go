@@ -0,0 +1,178 @@
+package nihil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type patchAddress struct {
+	City NilString `json:"city"`
+	Zip  NilString `json:"zip"`
+}
+
+type patchBase struct {
+	Version int64 `json:"version"`
+}
+
+type patchUser struct {
+	patchBase
+	Name     NilString           `json:"name"`
+	Nickname NilString           `json:"nickname"`
+	Age      NilInt32            `json:"age"`
+	Status   string              `json:"status"`
+	Tags     []string            `json:"tags"`
+	Address  patchAddress        `json:"address"`
+	Billing  *patchAddress       `json:"billing"`
+	Labels   map[string]string   `json:"labels"`
+	Scores   map[string]NilInt32 `json:"scores"`
+	Secret   string              `json:"-"`
+}
+
+func patchFixture() patchUser {
+	return patchUser{
+		patchBase: patchBase{Version: 1},
+		Name:      String("Alice"),
+		Nickname:  String("al"),
+		Age:       Int32(30),
+		Status:    "active",
+		Tags:      []string{"a"},
+		Address:   patchAddress{City: String("Jakarta"), Zip: String("10110")},
+		Billing:   &patchAddress{City: String("Bandung")},
+		Labels:    map[string]string{"team": "core", "tier": "gold"},
+		Secret:    "s3cret",
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		check    func(u patchUser) bool
+		expected []string
+	}{
+		{
+			name:     "null clears nihil field",
+			patch:    `{"nickname": null}`,
+			check:    func(u patchUser) bool { return !u.Nickname.Valid && u.Name == String("Alice") },
+			expected: []string{"nickname"},
+		},
+		{
+			name:     "missing keys are kept",
+			patch:    `{}`,
+			check:    func(u patchUser) bool { return reflect.DeepEqual(u, patchFixture()) },
+			expected: nil,
+		},
+		{
+			name:  "values replace fields",
+			patch: `{"name": "Bob", "age": 31, "tags": ["b", "c"], "status": "inactive"}`,
+			check: func(u patchUser) bool {
+				return u.Name == String("Bob") && u.Age == Int32(31) && len(u.Tags) == 2 && u.Status == "inactive"
+			},
+			expected: []string{"name", "age", "tags", "status"},
+		},
+		{
+			name:     "unchanged values are not reported",
+			patch:    `{"name": "Alice", "age": 30}`,
+			check:    func(u patchUser) bool { return u.Name == String("Alice") },
+			expected: nil,
+		},
+		{
+			name:     "null clears plain fields",
+			patch:    `{"status": null, "tags": null, "billing": null}`,
+			check:    func(u patchUser) bool { return u.Status == "" && u.Tags == nil && u.Billing == nil },
+			expected: []string{"status", "tags", "billing"},
+		},
+		{
+			name:  "objects merge into nested structs",
+			patch: `{"address": {"zip": null}, "billing": {"zip": "40111"}}`,
+			check: func(u patchUser) bool {
+				return u.Address.City == String("Jakarta") && !u.Address.Zip.Valid &&
+					u.Billing.City == String("Bandung") && u.Billing.Zip == String("40111")
+			},
+			expected: []string{"address.zip", "billing.zip"},
+		},
+		{
+			name:  "objects merge into maps",
+			patch: `{"labels": {"tier": null, "region": "id"}, "scores": {"math": 90}}`,
+			check: func(u patchUser) bool {
+				return reflect.DeepEqual(u.Labels, map[string]string{"team": "core", "region": "id"}) &&
+					u.Scores["math"] == Int32(90)
+			},
+			expected: []string{"labels.tier", "labels.region", "scores.math"},
+		},
+		{
+			name:     "embedded fields and ignored keys",
+			patch:    `{"version": 2, "Secret": "x", "unknown": 1}`,
+			check:    func(u patchUser) bool { return u.Version == 2 && u.Secret == "s3cret" },
+			expected: []string{"version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := patchFixture()
+			changed, err := ApplyMergePatchChanges(&u, []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !tt.check(u) {
+				t.Errorf("Unexpected result: %+v", u)
+			}
+			if !reflect.DeepEqual(changed, tt.expected) {
+				t.Errorf("Expected changes %q, got %q", tt.expected, changed)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatch_DoesNotModifyShared(t *testing.T) {
+	u := patchFixture()
+	billing, labels := u.Billing, u.Labels
+	if err := ApplyMergePatch(&u, []byte(`{"billing": {"city": "Bogor"}, "labels": {"team": null}}`)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if billing.City != String("Bandung") || labels["team"] != "core" {
+		t.Errorf("Expected the original pointer and map to be untouched, got %+v and %v", billing, labels)
+	}
+	if u.Billing.City != String("Bogor") || len(u.Labels) != 1 {
+		t.Errorf("Unexpected result: %+v", u)
+	}
+}
+
+func TestApplyMergePatch_Errors(t *testing.T) {
+	u := patchFixture()
+	err := ApplyMergePatch(&u, []byte(`{"name": "Bob", "address": {"zip": 10110}}`))
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected a DecodeError, got %v", err)
+	}
+	if de.Path != "address.zip" {
+		t.Errorf("Expected path address.zip, got %q", de.Path)
+	}
+	if !reflect.DeepEqual(u, patchFixture()) {
+		t.Errorf("Expected a failed patch to leave the target unchanged, got %+v", u)
+	}
+
+	tests := []struct {
+		name  string
+		dst   any
+		patch string
+		want  string
+	}{
+		{"non-pointer", u, `{}`, "expects a non-nil pointer to a struct"},
+		{"nil pointer", (*patchUser)(nil), `{}`, "expects a non-nil pointer to a struct"},
+		{"array patch", &u, `[1]`, "must be a JSON object"},
+		{"invalid JSON", &u, `{"name":`, "must be a JSON object"},
+		{"wrong type", &u, `{"status": 1}`, `field "status"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyMergePatch(tt.dst, []byte(tt.patch))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
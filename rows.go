@@ -0,0 +1,311 @@
+package nihil
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScanStruct scans the current row of rows into the struct dst points to,
+// matching columns to fields instead of relying on column order:
+//
+//	for rows.Next() {
+//		var u User
+//		if err := nihil.ScanStruct(rows, &u); err != nil {
+//			return err
+//		}
+//	}
+//
+// A column matches the field with the same GORM column setting, db tag or
+// snake_case name, falling back to a case-insensitive match; fields of
+// embedded structs are matched too. A column without a field is an error,
+// and fields without a column are left untouched.
+//
+// Nihil fields and other sql.Scanner fields scan themselves. Pointer,
+// slice, map and interface fields become nil on NULL, and NULL in any other
+// field is a *ScanError naming the column, as are values that cannot be
+// converted to the field type.
+func ScanStruct(rows *sql.Rows, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || !isRowStruct(rv.Type().Elem()) {
+		return fmt.Errorf("nihil: ScanStruct expects a non-nil pointer to a struct, got %T", dst)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes, err := planFor(rv.Type().Elem()).match(columns)
+	if err != nil {
+		return err
+	}
+	return scanRow(rows, columns, indexes, rv.Elem())
+}
+
+// ScanAll reads every remaining row of rows into a T, a struct or a pointer
+// to one, and closes rows. Columns map to fields as in ScanStruct.
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if !isRowStruct(st) {
+		return nil, fmt.Errorf("nihil: ScanAll expects a struct or a pointer to one, got %s", t)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := planFor(st).match(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []T
+	for rows.Next() {
+		var row T
+		rv := reflect.ValueOf(&row).Elem()
+		if rv.Kind() == reflect.Pointer {
+			rv.Set(reflect.New(st))
+			rv = rv.Elem()
+		}
+		if err := scanRow(rows, columns, indexes, rv); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func isRowStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isNullable(t)
+}
+
+// scanPlan maps the column names of a struct type to field indexes
+type scanPlan struct {
+	typ    reflect.Type
+	fields map[string][]int // by column name
+	folded map[string][]int // by lower-cased column name
+}
+
+var scanPlans sync.Map // map[reflect.Type]*scanPlan
+
+// planFor returns the cached scan plan of struct type t
+func planFor(t reflect.Type) *scanPlan {
+	if cached, ok := scanPlans.Load(t); ok {
+		return cached.(*scanPlan)
+	}
+
+	p := &scanPlan{typ: t, fields: map[string][]int{}, folded: map[string][]int{}}
+	p.collect(t, nil)
+	cached, _ := scanPlans.LoadOrStore(t, p)
+	return cached.(*scanPlan)
+}
+
+// collect adds the columns of the fields of t, with fields of the outer
+// struct winning over promoted ones
+func (p *scanPlan) collect(t reflect.Type, index []int) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && isEmbeddedColumns(sf.Type) {
+			// Like encoding/json, an embedded pointer to an unexported
+			// struct cannot be allocated, so its fields are not columns
+			if sf.IsExported() || sf.Type.Kind() != reflect.Pointer {
+				embedded = append(embedded, sf)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, ok := columnName(sf)
+		if !ok {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		if _, taken := p.fields[name]; !taken {
+			p.fields[name] = fieldIndex
+		}
+		if _, taken := p.folded[strings.ToLower(name)]; !taken {
+			p.folded[strings.ToLower(name)] = fieldIndex
+		}
+	}
+
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		p.collect(ft, append(append([]int(nil), index...), sf.Index...))
+	}
+}
+
+// match returns the field index of each column
+func (p *scanPlan) match(columns []string) ([][]int, error) {
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		index, ok := p.fields[column]
+		if !ok {
+			index, ok = p.folded[strings.ToLower(column)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("nihil: column %q has no matching field in %s", column, p.typ)
+		}
+		indexes[i] = index
+	}
+	return indexes, nil
+}
+
+// scanRow scans the current row into the fields of the struct v
+func scanRow(rows *sql.Rows, columns []string, indexes [][]int, v reflect.Value) error {
+	dest := make([]any, len(columns))
+	for i, index := range indexes {
+		dest[i] = &fieldScanner{column: columns[i], field: fieldByIndexAlloc(v, index)}
+	}
+
+	err := rows.Scan(dest...)
+	var se *ScanError
+	if errors.As(err, &se) {
+		return se // already names the column; drop database/sql's prefix
+	}
+	return err
+}
+
+// fieldByIndexAlloc returns the field at index, allocating nil embedded
+// struct pointers on the way
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldScanner scans one column into a struct field
+type fieldScanner struct {
+	column string
+	field  reflect.Value
+}
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+func (s *fieldScanner) Scan(value any) error {
+	err := scanInto(s.field, value)
+	var se *ScanError
+	if errors.As(err, &se) && se.Column == "" {
+		se.Column = s.column
+	}
+	return err
+}
+
+// scanInto stores a driver value in v, converting it to v's type
+func scanInto(v reflect.Value, value any) error {
+	if v.CanAddr() && v.Addr().Type().Implements(scannerType) {
+		return v.Addr().Interface().(sql.Scanner).Scan(value)
+	}
+
+	if value == nil {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			v.SetZero()
+			return nil
+		}
+		return &ScanError{Type: v.Type().String(), Reason: "the field is not nullable; use a nihil type or a pointer"}
+	}
+
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := scanInto(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	target := v.Type().String()
+	switch v.Kind() {
+	case reflect.String:
+		s, err := convertString(value)
+		if err != nil {
+			return scanError(target, value, "want text")
+		}
+		v.SetString(s)
+		return nil
+
+	case reflect.Bool:
+		b, err := convertBool(value)
+		if err != nil {
+			return scanError(target, value, "want a boolean, 0 or 1")
+		}
+		v.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := v.Type().Bits()
+		i, err := convertInteger(target, value, -1<<(bits-1), 1<<(bits-1)-1)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := convertUnsigned(target, value, math.MaxUint64>>(64-v.Type().Bits()))
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := convertFloat(target, value)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if s, ok := textOf(value); ok {
+				v.SetBytes([]byte(s))
+				return nil
+			}
+			return scanError(target, value, "want text or bytes")
+		}
+
+	case reflect.Interface:
+		if reflect.TypeOf(value).AssignableTo(v.Type()) {
+			v.Set(reflect.ValueOf(value))
+			return nil
+		}
+
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() {
+			t, err := convertTime(value)
+			if err != nil {
+				return scanError(target, value, "want a time, or timestamp text")
+			}
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+	return scanError(target, value, "unsupported field type")
+}
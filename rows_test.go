@@ -0,0 +1,310 @@
+package nihil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// RowAudit is exported so rowUser can embed a pointer to it
+type RowAudit struct {
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt NilTime
+}
+
+type rowUser struct {
+	ID       int64
+	Name     string
+	Email    NilString `db:"email_address"`
+	Age      NilInt32
+	Nickname *string
+	Score    float64
+	Active   bool
+	Ignored  string `db:"-"`
+	*RowAudit
+	*rowSecret
+}
+
+type rowSecret struct {
+	Secret string
+}
+
+// rowsDriver is a database/sql driver serving canned results, so the row
+// mapper is tested through database/sql without a real database. The data
+// source name selects the result registered by queryRows.
+type rowsDriver struct{}
+
+type rowsResult struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var (
+	rowsResults sync.Map // data source name -> rowsResult
+	rowsCount   atomic.Int64
+)
+
+func init() {
+	sql.Register("nihil-rows", rowsDriver{})
+}
+
+func (rowsDriver) Open(name string) (driver.Conn, error) {
+	result, ok := rowsResults.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no result registered as %q", name)
+	}
+	return rowsConn{result.(rowsResult)}, nil
+}
+
+type rowsConn struct{ result rowsResult }
+
+func (c rowsConn) Prepare(string) (driver.Stmt, error) { return rowsStmt(c), nil }
+func (rowsConn) Close() error                          { return nil }
+func (rowsConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+type rowsStmt struct{ result rowsResult }
+
+func (rowsStmt) Close() error                               { return nil }
+func (rowsStmt) NumInput() int                              { return 0 }
+func (rowsStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+func (s rowsStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &rowsCursor{result: s.result}, nil
+}
+
+type rowsCursor struct {
+	result rowsResult
+	next   int
+}
+
+func (r *rowsCursor) Columns() []string { return r.result.columns }
+func (r *rowsCursor) Close() error      { return nil }
+func (r *rowsCursor) Next(dest []driver.Value) error {
+	if r.next == len(r.result.values) {
+		return io.EOF
+	}
+	copy(dest, r.result.values[r.next])
+	r.next++
+	return nil
+}
+
+// queryRows returns rows of the given columns and values, as a driver
+// hands them to database/sql
+func queryRows(t *testing.T, columns []string, values ...[]driver.Value) *sql.Rows {
+	name := strconv.FormatInt(rowsCount.Add(1), 10)
+	rowsResults.Store(name, rowsResult{columns: columns, values: values})
+	t.Cleanup(func() { rowsResults.Delete(name) })
+
+	db, err := sql.Open("nihil-rows", name)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	return rows
+}
+
+// userColumns and userValues are a users table in the types SQLite
+// drivers return: int64, float64, bool, text as string, and nil
+var (
+	userColumns = []string{"id", "name", "email_address", "age", "nickname", "score", "active", "created_at", "updated_at"}
+	userValues  = [][]driver.Value{
+		{int64(1), "Alice", "alice@example.com", int64(30), "al", 9.5, true, "2024-01-02 03:04:05", nil},
+		{int64(2), "Bob", nil, nil, nil, float64(7), false, "2024-02-03 04:05:06", "2024-03-04 05:06:07"},
+	}
+)
+
+// queryUsers returns the rows of the users table with only the given
+// columns, like SELECT columns FROM users ORDER BY id
+func queryUsers(t *testing.T, columns ...string) *sql.Rows {
+	var values [][]driver.Value
+	for _, row := range userValues {
+		var projected []driver.Value
+		for _, column := range columns {
+			projected = append(projected, row[slices.Index(userColumns, column)])
+		}
+		values = append(values, projected)
+	}
+	return queryRows(t, columns, values...)
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := queryUsers(t, userColumns...)
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("Expected a row")
+	}
+	u := rowUser{Ignored: "kept"}
+	if err := ScanStruct(rows, &u); err != nil {
+		t.Fatalf("ScanStruct failed: %v", err)
+	}
+
+	if u.ID != 1 || u.Name != "Alice" || u.Score != 9.5 || !u.Active {
+		t.Errorf("Expected plain fields of Alice, got %+v", u)
+	}
+	if u.Email != String("alice@example.com") || u.Age != Int32(30) {
+		t.Errorf("Expected valid nihil fields, got %v and %v", u.Email, u.Age)
+	}
+	if u.Nickname == nil || *u.Nickname != "al" {
+		t.Errorf("Expected nickname al, got %v", u.Nickname)
+	}
+	if u.Ignored != "kept" {
+		t.Errorf("Expected the ignored field to be untouched, got %q", u.Ignored)
+	}
+	if u.RowAudit == nil {
+		t.Fatal("Expected the embedded pointer to be allocated")
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !u.CreatedAt.Equal(want) {
+		t.Errorf("Expected created_at %v, got %v", want, u.CreatedAt)
+	}
+	if u.UpdatedAt.Valid {
+		t.Errorf("Expected null updated_at, got %v", u.UpdatedAt)
+	}
+}
+
+func TestScanStruct_GormIgnored(t *testing.T) {
+	type report struct {
+		ID    int64
+		Total int64  `gorm:"-:migration"`
+		Cache string `gorm:"-:all"`
+	}
+
+	rows := queryRows(t, []string{"id", "total"}, []driver.Value{int64(1), int64(42)})
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("Expected a row")
+	}
+	r := report{Cache: "kept"}
+	if err := ScanStruct(rows, &r); err != nil {
+		t.Fatalf("ScanStruct failed: %v", err)
+	}
+	if r.Total != 42 {
+		t.Errorf("Expected a field ignored only by migrations to be scanned, got %d", r.Total)
+	}
+	if r.Cache != "kept" {
+		t.Errorf("Expected the ignored field to be untouched, got %q", r.Cache)
+	}
+}
+
+func TestScanStruct_Unsigned(t *testing.T) {
+	type counter struct {
+		Hits  uint64
+		Size  uint
+		Small uint8
+	}
+
+	// MySQL returns BIGINT UNSIGNED values above math.MaxInt64 as text
+	rows := queryRows(t, []string{"hits", "size", "small"}, []driver.Value{[]byte("18446744073709551615"), "9223372036854775808", int64(255)})
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("Expected a row")
+	}
+	var c counter
+	if err := ScanStruct(rows, &c); err != nil {
+		t.Fatalf("ScanStruct failed: %v", err)
+	}
+	if c.Hits != math.MaxUint64 || c.Size != 1<<63 || c.Small != 255 {
+		t.Errorf("Expected the full unsigned range, got %+v", c)
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	rows := queryUsers(t, "id", "name", "email_address", "age", "nickname")
+
+	users, err := ScanAll[*rowUser](rows)
+	if err != nil {
+		t.Fatalf("ScanAll failed: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	bob := users[1]
+	if bob.Name != "Bob" || bob.Email.Valid || bob.Age.Valid || bob.Nickname != nil {
+		t.Errorf("Expected Bob with null fields, got %+v", bob)
+	}
+	if bob.RowAudit != nil {
+		t.Errorf("Expected no embedded struct without its columns, got %+v", bob.RowAudit)
+	}
+}
+
+func TestScanAll_ColumnNames(t *testing.T) {
+	rows := queryRows(t, []string{"ID", "NAME"}, []driver.Value{int64(1), "Alice"}, []driver.Value{int64(2), "Bob"})
+
+	users, err := ScanAll[rowUser](rows)
+	if err != nil {
+		t.Fatalf("ScanAll failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[0].Name != "Alice" {
+		t.Errorf("Expected case-insensitive column matches, got %+v", users)
+	}
+}
+
+func TestScanAll_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		values  []driver.Value
+		want    string
+	}{
+		{"null into plain field", []string{"id", "active"}, []driver.Value{int64(2), nil},
+			`nihil: column "active": cannot scan NULL into bool: the field is not nullable`},
+		{"unexported embedded pointer", []string{"secret"}, []driver.Value{int64(1)},
+			`nihil: column "secret" has no matching field in nihil.rowUser`},
+		{"unknown column", []string{"id", "unknown"}, []driver.Value{int64(1), int64(1)},
+			`nihil: column "unknown" has no matching field in nihil.rowUser`},
+		{"out of range", []string{"age"}, []driver.Value{int64(3000000000)},
+			`nihil: column "age": cannot scan int64 (3000000000) into NilInt32`},
+		{"wrong type", []string{"score"}, []driver.Value{"x"},
+			`nihil: column "score": cannot scan`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := queryRows(t, tt.columns, tt.values)
+			_, err := ScanAll[rowUser](rows)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("Expected error starting with %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestScanStruct_ScanError(t *testing.T) {
+	rows := queryRows(t, []string{"active"}, []driver.Value{"Alice"})
+	defer rows.Close()
+	rows.Next()
+
+	var u rowUser
+	err := ScanStruct(rows, &u)
+	var se *ScanError
+	if !errors.As(err, &se) {
+		t.Fatalf("Expected a *ScanError, got %v", err)
+	}
+	if se.Column != "active" || se.Type != "bool" {
+		t.Errorf("Expected column active and type bool, got %q and %q", se.Column, se.Type)
+	}
+}
+
+func TestScanStruct_InvalidDestination(t *testing.T) {
+	for _, dst := range []any{rowUser{}, (*rowUser)(nil), new(int), new(NilString)} {
+		if err := ScanStruct(nil, dst); err == nil {
+			t.Errorf("Expected an error for %T", dst)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package nihil
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts lists the textual timestamp layouts accepted when scanning a
+// NilTime from a string or []byte, in the order they are tried.
+// Layouts without a zone are interpreted as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// scanNullable is a generic helper for database scanning
+// It resets the value on NULL and converts anything else with convert
+func scanNullable[T any](n nullableJSON[T], value any, convert func(any) (T, error)) error {
+	if value == nil {
+		var zero T
+		n.setValue(zero)
+		n.setValid(false)
+		return nil
+	}
+
+	v, err := convert(value)
+	if err != nil {
+		return err
+	}
+
+	n.setValue(v)
+	n.setValid(true)
+	return nil
+}
+
+// scanError reports a driver value that could not be converted into target
+func scanError(target string, value any, reason string) error {
+	return &ScanError{Type: target, Source: reflect.TypeOf(value), Value: value, Reason: reason}
+}
+
+// describeValue renders a driver value for error messages, quoting text
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case []byte:
+		return strconv.Quote(string(v))
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// textOf returns the textual form of string and []byte values
+func textOf(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+// convertInteger converts integer, integral float, bool and numeric text
+// values into an int64 that fits in [minValue, maxValue]
+func convertInteger(target string, value any, minValue, maxValue int64) (int64, error) {
+	var i int64
+
+	if s, ok := textOf(value); ok {
+		s = strings.TrimSpace(s)
+		parsed, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			f, ferr := strconv.ParseFloat(s, 64)
+			if ferr != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return 0, scanError(target, value, "want an integer, or integer text")
+			}
+			parsed = int64(f)
+		}
+		i = parsed
+	} else {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u := rv.Uint()
+			if u > math.MaxInt64 {
+				return 0, scanError(target, value, "value out of range")
+			}
+			i = int64(u)
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return 0, scanError(target, value, "want an integral number")
+			}
+			i = int64(f)
+		case reflect.Bool:
+			if rv.Bool() {
+				i = 1
+			}
+		default:
+			return 0, scanError(target, value, "want an integer, or integer text")
+		}
+	}
+
+	if i < minValue || i > maxValue {
+		return 0, scanError(target, value, "value out of range")
+	}
+	return i, nil
+}
+
+// convertUnsigned converts values as convertInteger does into a uint64 that
+// is at most maxValue, taking unsigned values and text above math.MaxInt64
+func convertUnsigned(target string, value any, maxValue uint64) (uint64, error) {
+	var u uint64
+
+	rv := reflect.ValueOf(value)
+	if s, ok := textOf(value); ok {
+		parsed, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			i, err := convertInteger(target, value, 0, math.MaxInt64)
+			if err != nil {
+				return 0, err
+			}
+			parsed = uint64(i)
+		}
+		u = parsed
+	} else if k := rv.Kind(); k >= reflect.Uint && k <= reflect.Uintptr {
+		u = rv.Uint()
+	} else {
+		i, err := convertInteger(target, value, 0, math.MaxInt64)
+		if err != nil {
+			return 0, err
+		}
+		u = uint64(i)
+	}
+
+	if u > maxValue {
+		return 0, scanError(target, value, "value out of range")
+	}
+	return u, nil
+}
+
+// convertFloat converts numeric and numeric text values into a float64
+func convertFloat(target string, value any) (float64, error) {
+	if s, ok := textOf(value); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return 0, scanError(target, value, "want a number, or numeric text")
+		}
+		return f, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	}
+	return 0, scanError(target, value, "want a number, or numeric text")
+}
+
+func convertByte(value any) (byte, error) {
+	i, err := convertInteger("NilByte", value, 0, math.MaxUint8)
+	return byte(i), err
+}
+
+func convertInt16(value any) (int16, error) {
+	i, err := convertInteger("NilInt16", value, math.MinInt16, math.MaxInt16)
+	return int16(i), err
+}
+
+func convertInt32(value any) (int32, error) {
+	i, err := convertInteger("NilInt32", value, math.MinInt32, math.MaxInt32)
+	return int32(i), err
+}
+
+func convertInt64(value any) (int64, error) {
+	return convertInteger("NilInt64", value, math.MinInt64, math.MaxInt64)
+}
+
+func convertFloat64(value any) (float64, error) {
+	return convertFloat("NilFloat64", value)
+}
+
+// convertBool accepts booleans, the integers 0 and 1, and the usual textual
+// spellings used by drivers and legacy schemas (t/f, y/n, yes/no, 1/0)
+func convertBool(value any) (bool, error) {
+	if s, ok := textOf(value); ok {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "1", "t", "true", "y", "yes":
+			return true, nil
+		case "0", "f", "false", "n", "no":
+			return false, nil
+		}
+		return false, scanError("NilBool", value, "want one of true/false, t/f, yes/no, y/n, 1/0")
+	}
+
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+
+	i, err := convertInteger("NilBool", value, 0, 1)
+	if err != nil {
+		return false, scanError("NilBool", value, "want a boolean, 0 or 1")
+	}
+	return i == 1, nil
+}
+
+// convertString accepts text as well as the scalar types database/sql
+// itself would render into a string destination
+func convertString(value any) (string, error) {
+	if s, ok := textOf(value); ok {
+		return s, nil
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	}
+	return "", scanError("NilString", value, "want text or a scalar value")
+}
+
+// convertTime accepts time.Time values and text in any of timeLayouts
+func convertTime(value any) (time.Time, error) {
+	if t, ok := value.(time.Time); ok {
+		return t, nil
+	}
+
+	s, ok := textOf(value)
+	if !ok {
+		return time.Time{}, scanError("NilTime", value, "want a time.Time or timestamp text")
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, scanError("NilTime", value, "timestamp text matches no known layout")
+}
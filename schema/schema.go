@@ -0,0 +1,508 @@
+// Package schema generates JSON Schema and OpenAPI schema objects for Go
+// types using the nihil nullable types.
+//
+// Generators that reflect on the sql.Null* layout describe nihil fields as
+// objects with String and Valid properties. This package describes them as
+// the JSON they marshal to: the value's type, or null.
+//
+//	type User struct {
+//		ID       int64           `json:"id"`
+//		Email    nihil.NilString `json:"email" nihil:"required,format=email,max=255"`
+//		Birthday nihil.NilTime   `json:"birthday" nihil:"format=date"`
+//	}
+//
+//	s, err := schema.JSONSchema(User{})
+//	// "email": {"type": "string", "format": "email", "minLength": 1, "maxLength": 255}
+//	// "birthday": {"type": ["string", "null"], "format": "date"}
+//
+//	s, err = schema.OpenAPI(User{})
+//	// "birthday": {"type": "string", "format": "date", "nullable": true}
+//
+// Constraints come from the `nihil:"..."` tags understood by nihil.Validate:
+// min and max become minimum and maximum for numbers and minLength and
+// maxLength for strings, len fixes the length, pattern and oneof map to
+// pattern and enum, and notnull and required make the field non-nullable,
+// required also listing it in the object's required properties. The extra
+// format option sets the schema format, e.g. uuid, email or date; NilTime
+// defaults to date-time.
+//
+// Recursive struct types are defined once in $defs and referenced with
+// $ref: "#/$defs/Node" in JSON Schema, "#/components/schemas/Node" in
+// OpenAPI, whose caller adds the $defs to the document's components.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+	"github.com/mrrizkin/nihil/internal/jsonfield"
+)
+
+// Draft202012 is the $schema of the documents returned by JSONSchema
+const Draft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema or OpenAPI 3.0 schema object
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"` // a type name, or a list of them
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"` // OpenAPI 3.0 only
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"` // OpenAPI 3.0 nullable references
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of v's type, where
+// nullable values have a type list ending in "null"
+func JSONSchema(v any) (*Schema, error) {
+	s, err := (&generator{}).document(typeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft202012
+	return s, nil
+}
+
+// OpenAPI returns the OpenAPI 3.0 schema object of v's type, where
+// nullable values are marked with nullable: true. Recursive types refer to
+// components/schemas, which the caller fills from the returned $defs.
+func OpenAPI(v any) (*Schema, error) {
+	return (&generator{openAPI: true}).document(typeOf(v))
+}
+
+// typeOf returns the type of v, a value or a pointer to one
+func typeOf(v any) reflect.Type {
+	if v == nil {
+		return nil
+	}
+	return indirect(reflect.TypeOf(v))
+}
+
+type generator struct {
+	openAPI bool
+	visited []reflect.Type          // struct types being generated
+	names   map[reflect.Type]string // $defs names of recursive types
+	defs    map[string]*Schema
+}
+
+// document returns the schema of t with the $defs of its recursive types
+func (g *generator) document(t reflect.Type) (*Schema, error) {
+	s, err := g.generate(t, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s, nil
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	optionalType      = reflect.TypeFor[nihil.Optional[any]]()
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// generate returns the schema of t; path locates t in errors
+func (g *generator) generate(t reflect.Type, path string) (*Schema, error) {
+	if t == nil {
+		return &Schema{}, nil
+	}
+	if kind, ok := kindOf(t); ok {
+		return g.nullable(nihilSchema(kind, t)), nil
+	}
+	if value, ok := optionalValue(t); ok {
+		s, err := g.generate(value, path)
+		if err != nil {
+			return nil, err
+		}
+		return g.nullable(s), nil
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case t.Kind() != reflect.Pointer && (t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)):
+		return &Schema{}, nil // custom JSON of unknown shape
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s, err := g.generate(t.Elem(), path)
+		if err != nil {
+			return nil, err
+		}
+		return g.nullable(s), nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Uint8, reflect.Uint16:
+		return integerSchema(t), nil
+	case reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}, nil
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.generate(t.Elem(), path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if !isMapKey(t.Key()) {
+			return nil, unsupported(t, path)
+		}
+		values, err := g.generate(t.Elem(), path+"{}")
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.object(t, path)
+	}
+	return nil, unsupported(t, path)
+}
+
+// object returns the schema of a struct type, or a $ref to it when the
+// type is recursive
+func (g *generator) object(t reflect.Type, path string) (*Schema, error) {
+	if _, ok := g.defs[g.names[t]]; ok || slices.Contains(g.visited, t) {
+		return g.ref(t), nil
+	}
+	g.visited = append(g.visited, t)
+	defer func() { g.visited = g.visited[:len(g.visited)-1] }()
+
+	s, err := g.properties(t, path)
+	if err != nil {
+		return nil, err
+	}
+	if _, recursive := g.names[t]; recursive {
+		g.defs[g.names[t]] = s
+		return g.ref(t), nil
+	}
+	return s, nil
+}
+
+// ref returns a reference to the $defs entry of a recursive type, naming
+// the entry on first use
+func (g *generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		if g.names == nil {
+			g.names = map[reflect.Type]string{}
+			g.defs = map[string]*Schema{}
+		}
+		name = defName(t, g.names)
+		g.names[t] = name
+	}
+	if g.openAPI {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// defName returns a $defs name for t, its type name with characters other
+// than letters, digits, ".", "-" and "_" replaced, made unique among names
+func defName(t reflect.Type, names map[reflect.Type]string) string {
+	base := strings.Map(func(r rune) rune {
+		if r < 0x80 && (r == '.' || r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, t.Name())
+
+	taken := func(name string) bool {
+		for _, other := range names {
+			if other == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := base
+	for n := 2; taken(name); n++ {
+		name = base + strconv.Itoa(n)
+	}
+	return name
+}
+
+// properties returns the schema of a struct type from its JSON-visible fields
+func (g *generator) properties(t reflect.Type, path string) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range jsonfield.Fields(t, isNihil) {
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+
+		// Optional fields may be left out and set to null, with the
+		// constraints of their value
+		value, isOptional := optionalValue(f.Type)
+		if isOptional {
+			f.Type = value
+		}
+
+		var (
+			fs  *Schema
+			err error
+		)
+		if kind, ok := kindOf(indirect(f.Type)); ok {
+			fs, err = g.nihilField(kind, f)
+		} else {
+			fs, err = g.generate(f.Type, fieldPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if isOptional {
+			s.Properties[f.Name] = g.nullable(fs)
+			continue
+		}
+		s.Properties[f.Name] = fs
+		if _, ok := nihil.TagOption(f.Tag, "required"); ok {
+			s.Required = append(s.Required, f.Name)
+		}
+	}
+	return s, nil
+}
+
+// nihilField returns the schema of a nihil field with its tag constraints
+func (g *generator) nihilField(kind nihil.Kind, f jsonfield.Field) (*Schema, error) {
+	s := nihilSchema(kind, indirect(f.Type))
+
+	if format, ok := nihil.TagOption(f.Tag, "format"); ok {
+		s.Format = format
+	}
+	for _, rule := range []string{"min", "max", "len"} {
+		param, ok := nihil.TagOption(f.Tag, rule)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("nihil: schema: field %q: %s=%s: want a number", f.Name, rule, param)
+		}
+		switch {
+		case s.Type == "string" && n == math.Trunc(n):
+			length := int(n)
+			if rule != "max" {
+				s.MinLength = &length
+			}
+			if rule != "min" {
+				s.MaxLength = &length
+			}
+		case (s.Type == "integer" || s.Type == "number") && rule == "min":
+			s.Minimum = &n
+		case (s.Type == "integer" || s.Type == "number") && rule == "max":
+			s.Maximum = &n
+		}
+	}
+	if pattern, ok := nihil.TagOption(f.Tag, "pattern"); ok && s.Type == "string" {
+		s.Pattern = pattern
+	}
+	if oneof, ok := nihil.TagOption(f.Tag, "oneof"); ok {
+		s.Enum = nil
+		for _, option := range strings.Fields(oneof) {
+			s.Enum = append(s.Enum, enumValue(s, option))
+		}
+	}
+
+	_, notnull := nihil.TagOption(f.Tag, "notnull")
+	_, required := nihil.TagOption(f.Tag, "required")
+	if required && s.Type == "string" && s.MinLength == nil {
+		s.MinLength = ptr(1) // required rejects the empty string
+	}
+	if notnull || required {
+		return s, nil
+	}
+	return g.nullable(s), nil
+}
+
+// nihilSchema returns the non-null schema of a nihil type
+func nihilSchema(kind nihil.Kind, t reflect.Type) *Schema {
+	switch kind {
+	case nihil.KindByte:
+		return &Schema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(255.0)}
+	case nihil.KindBool:
+		return &Schema{Type: "boolean"}
+	case nihil.KindFloat64:
+		return &Schema{Type: "number", Format: "double"}
+	case nihil.KindInt16:
+		return &Schema{Type: "integer", Minimum: ptr(float64(math.MinInt16)), Maximum: ptr(float64(math.MaxInt16))}
+	case nihil.KindInt32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case nihil.KindInt64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case nihil.KindTime:
+		return &Schema{Type: "string", Format: "date-time"}
+	case nihil.KindEnum:
+		s := &Schema{Type: "string"}
+		values := reflect.Zero(t).Interface().(interface{ RegisteredValues() []string }).RegisteredValues()
+		for _, v := range values {
+			s.Enum = append(s.Enum, v)
+		}
+		return s
+	}
+	return &Schema{Type: "string"}
+}
+
+// nullable makes s accept null as well: a type list ending in "null" for
+// JSON Schema, nullable: true for OpenAPI. Enumerations list null too.
+func (g *generator) nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		if g.openAPI {
+			return &Schema{Nullable: true, AllOf: []*Schema{s}}
+		}
+		return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+	}
+	if s.Type == nil {
+		return s // already accepts anything
+	}
+	if g.openAPI {
+		s.Nullable = true
+	} else if name, ok := s.Type.(string); ok {
+		s.Type = []string{name, "null"}
+	}
+	if len(s.Enum) > 0 && !containsNull(s.Enum) {
+		s.Enum = append(s.Enum, nil)
+	}
+	return s
+}
+
+func containsNull(values []any) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// enumValue converts a oneof option to the JSON type of s
+func enumValue(s *Schema, option string) any {
+	switch s.Type {
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(option, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(option); err == nil {
+			return b
+		}
+	}
+	return option
+}
+
+// integerSchema returns an integer schema bounded by the range of t
+func integerSchema(t reflect.Type) *Schema {
+	bits := t.Bits()
+	if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr {
+		return &Schema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(float64(uint64(1)<<bits - 1))}
+	}
+	limit := float64(int64(1) << (bits - 1))
+	return &Schema{Type: "integer", Minimum: ptr(-limit), Maximum: ptr(limit - 1)}
+}
+
+// isMapKey reports whether encoding/json can encode map keys of type t
+func isMapKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return t.Implements(textMarshalerType)
+}
+
+// optionalValue returns T when t is nihil.Optional[T]
+func optionalValue(t reflect.Type) (reflect.Type, bool) {
+	if t.PkgPath() != optionalType.PkgPath() || !strings.HasPrefix(t.Name(), "Optional[") {
+		return nil, false
+	}
+	value, _ := t.FieldByName("Value")
+	return value.Type, true
+}
+
+// nihilKinds maps the nihil types to their kinds; NilEnum instantiations
+// are recognized by name, like Optional ones
+var nihilKinds = map[reflect.Type]nihil.Kind{
+	reflect.TypeFor[nihil.NilByte]():    nihil.KindByte,
+	reflect.TypeFor[nihil.NilBool]():    nihil.KindBool,
+	reflect.TypeFor[nihil.NilFloat64](): nihil.KindFloat64,
+	reflect.TypeFor[nihil.NilInt16]():   nihil.KindInt16,
+	reflect.TypeFor[nihil.NilInt32]():   nihil.KindInt32,
+	reflect.TypeFor[nihil.NilInt64]():   nihil.KindInt64,
+	reflect.TypeFor[nihil.NilString]():  nihil.KindString,
+	reflect.TypeFor[nihil.NilTime]():    nihil.KindTime,
+}
+
+// kindOf returns the kind of a nihil type, or of a struct type embedding
+// one as its first field, such as the nihilgorm wrapper types
+func kindOf(t reflect.Type) (nihil.Kind, bool) {
+	for {
+		if kind, ok := nihilKinds[t]; ok {
+			return kind, true
+		}
+		if t.PkgPath() == optionalType.PkgPath() && strings.HasPrefix(t.Name(), "NilEnum[") {
+			return nihil.KindEnum, true
+		}
+		if t.Kind() != reflect.Struct || t.NumField() == 0 || !t.Field(0).Anonymous {
+			return 0, false
+		}
+		t = t.Field(0).Type
+	}
+}
+
+// isNihil reports whether t is a nihil type, which is a field of its own
+// rather than an embedded struct
+func isNihil(t reflect.Type) bool {
+	_, ok := kindOf(t)
+	return ok
+}
+
+// indirect returns the type t points to, through any number of pointers
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func unsupported(t reflect.Type, path string) error {
+	return fmt.Errorf("nihil: schema: unsupported type %s at %q", t, path)
+}
+
+func ptr[T any](v T) *T { return &v }
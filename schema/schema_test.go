@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrrizkin/nihil"
+)
+
+type testStatus string
+
+func init() {
+	nihil.RegisterEnum[testStatus]("active", "inactive")
+}
+
+// testWrapper embeds a nihil type like the nihilgorm wrapper types do
+type testWrapper struct{ nihil.NilString }
+
+type testAddress struct {
+	Zip nihil.NilString `json:"zip" nihil:"len=5,pattern=^[0-9]+$"`
+}
+
+type testBase struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testUser struct {
+	testBase
+	ID       int64                     `json:"id"`
+	Email    nihil.NilString           `json:"email" nihil:"required,format=email,max=255"`
+	Nickname nihil.NilString           `json:"nickname,omitempty"`
+	UUID     nihil.NilString           `json:"uuid" nihil:"format=uuid"`
+	Birthday nihil.NilTime             `json:"birthday" nihil:"format=date"`
+	LastSeen *nihil.NilTime            `json:"last_seen"`
+	Age      nihil.NilInt32            `json:"age" nihil:"min=0,max=150"`
+	Level    nihil.NilByte             `json:"level"`
+	Score    nihil.NilFloat64          `json:"score" nihil:"notnull"`
+	Active   nihil.NilBool             `json:"active"`
+	Status   nihil.NilEnum[testStatus] `json:"status"`
+	Size     nihil.NilString           `json:"size" nihil:"oneof=S M L"`
+	Alias    testWrapper               `json:"alias"`
+	Address  *testAddress              `json:"address"`
+	Tags     []nihil.NilString         `json:"tags"`
+	Labels   map[string]string         `json:"labels"`
+	Secret   string                    `json:"-"`
+}
+
+func property(t *testing.T, s *Schema, path ...string) string {
+	t.Helper()
+	for _, name := range path {
+		next, ok := s.Properties[name]
+		if !ok {
+			t.Fatalf("Property %q not found", name)
+		}
+		s = next
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func TestJSONSchema(t *testing.T) {
+	s, err := JSONSchema(&testUser{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Schema != Draft202012 || s.Type != "object" {
+		t.Errorf("Expected a draft 2020-12 object, got %s %v", s.Schema, s.Type)
+	}
+
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"id"}, `{"type":"integer","format":"int64"}`},
+		{[]string{"created_at"}, `{"type":"string","format":"date-time"}`},
+		{[]string{"email"}, `{"type":"string","format":"email","minLength":1,"maxLength":255}`},
+		{[]string{"nickname"}, `{"type":["string","null"]}`},
+		{[]string{"uuid"}, `{"type":["string","null"],"format":"uuid"}`},
+		{[]string{"birthday"}, `{"type":["string","null"],"format":"date"}`},
+		{[]string{"last_seen"}, `{"type":["string","null"],"format":"date-time"}`},
+		{[]string{"age"}, `{"type":["integer","null"],"format":"int32","minimum":0,"maximum":150}`},
+		{[]string{"level"}, `{"type":["integer","null"],"minimum":0,"maximum":255}`},
+		{[]string{"score"}, `{"type":"number","format":"double"}`},
+		{[]string{"active"}, `{"type":["boolean","null"]}`},
+		{[]string{"status"}, `{"type":["string","null"],"enum":["active","inactive",null]}`},
+		{[]string{"size"}, `{"type":["string","null"],"enum":["S","M","L",null]}`},
+		{[]string{"alias"}, `{"type":["string","null"]}`},
+		{[]string{"address", "zip"}, `{"type":["string","null"],"minLength":5,"maxLength":5,"pattern":"^[0-9]+$"}`},
+		{[]string{"tags"}, `{"type":"array","items":{"type":["string","null"]}}`},
+		{[]string{"labels"}, `{"type":"object","additionalProperties":{"type":"string"}}`},
+	}
+
+	for _, tt := range tests {
+		if got := property(t, s, tt.path...); got != tt.expected {
+			t.Errorf("%v: expected %s, got %s", tt.path, tt.expected, got)
+		}
+	}
+
+	if address := s.Properties["address"]; !slices.Equal(address.Type.([]string), []string{"object", "null"}) {
+		t.Errorf("Expected a nullable address object, got %v", address.Type)
+	}
+	if _, ok := s.Properties["Secret"]; ok {
+		t.Error("Expected json:\"-\" fields to be skipped")
+	}
+	if !slices.Equal(s.Required, []string{"email"}) {
+		t.Errorf("Expected email to be required, got %v", s.Required)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	s, err := OpenAPI(testUser{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Schema != "" {
+		t.Errorf("Expected no $schema, got %s", s.Schema)
+	}
+
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"email"}, `{"type":"string","format":"email","minLength":1,"maxLength":255}`},
+		{[]string{"birthday"}, `{"type":"string","format":"date","nullable":true}`},
+		{[]string{"age"}, `{"type":"integer","format":"int32","nullable":true,"minimum":0,"maximum":150}`},
+		{[]string{"status"}, `{"type":"string","nullable":true,"enum":["active","inactive",null]}`},
+		{[]string{"address"}, `{"type":"object","nullable":true,"properties":{"zip":{"type":"string","nullable":true,"minLength":5,"maxLength":5,"pattern":"^[0-9]+$"}}}`},
+	}
+
+	for _, tt := range tests {
+		if got := property(t, s, tt.path...); got != tt.expected {
+			t.Errorf("%v: expected %s, got %s", tt.path, tt.expected, got)
+		}
+	}
+}
+
+type testNode struct {
+	Name     nihil.NilString `json:"name"`
+	Children []testNode      `json:"children"`
+}
+
+func TestSchema_Optional(t *testing.T) {
+	type patch struct {
+		Email nihil.Optional[nihil.NilString] `json:"email,omitzero" nihil:"required,max=255"`
+		Age   nihil.Optional[int32]           `json:"age,omitzero"`
+		Tags  []nihil.Optional[string]        `json:"tags"`
+	}
+
+	s, err := JSONSchema(patch{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	got, _ := json.Marshal(s)
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"age":{"type":["integer","null"],"format":"int32"},` +
+		`"email":{"type":["string","null"],"minLength":1,"maxLength":255},` +
+		`"tags":{"type":"array","items":{"type":["string","null"]}}}}`
+	if string(got) != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestSchema_Recursive(t *testing.T) {
+	type list struct {
+		Value int64 `json:"value"`
+		Next  *list `json:"next"`
+	}
+	type tree struct {
+		Root  testNode `json:"root"`
+		Other testNode `json:"other"`
+		Tail  list     `json:"tail"`
+	}
+
+	s, err := JSONSchema(tree{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	got, _ := json.Marshal(s)
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"other":{"$ref":"#/$defs/testNode"},"root":{"$ref":"#/$defs/testNode"},"tail":{"$ref":"#/$defs/list"}},` +
+		`"$defs":{"list":{"type":"object","properties":{"next":{"anyOf":[{"$ref":"#/$defs/list"},{"type":"null"}]},"value":{"type":"integer","format":"int64"}}},` +
+		`"testNode":{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/testNode"}},"name":{"type":["string","null"]}}}}}`
+	if string(got) != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	s, err = OpenAPI(&list{})
+	if err != nil {
+		t.Fatalf("OpenAPI failed: %v", err)
+	}
+	got, _ = json.Marshal(s)
+	expected = `{"$ref":"#/components/schemas/list","$defs":{"list":{"type":"object","properties":{` +
+		`"next":{"nullable":true,"allOf":[{"$ref":"#/components/schemas/list"}]},"value":{"type":"integer","format":"int64"}}}}}`
+	if string(got) != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestSchema_Errors(t *testing.T) {
+	if _, err := JSONSchema(struct{ C chan int }{}); err == nil {
+		t.Error("Expected an error for a channel")
+	}
+	if _, err := OpenAPI(struct {
+		Age nihil.NilInt32 `nihil:"min=zero"`
+	}{}); err == nil {
+		t.Error("Expected an error for a malformed constraint")
+	}
+}
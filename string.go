@@ -23,7 +23,7 @@ func (n *NilString) isValid() bool         { return n.Valid }
 func (n *NilString) getValue() string      { return n.String }
 func (n *NilString) setValid(valid bool)   { n.Valid = valid }
 func (n *NilString) setValue(value string) { n.String = value }
-func (n *NilString) scan(value any) error  { return (*sql.NullString)(n).Scan(value) }
+func (n *NilString) scan(value any) error  { return scanNullable(n, value, convertString) }
 func (n *NilString) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
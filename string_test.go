@@ -76,3 +76,34 @@ func TestNilString_JSONUnmarshaling(t *testing.T) {
 		})
 	}
 }
+
+func TestNilString_Scan(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         any
+		expectedValid bool
+		expectedValue string
+	}{
+		{"nil", nil, false, ""},
+		{"string", "hello", true, "hello"},
+		{"bytes", []byte("bytes"), true, "bytes"},
+		{"int64", int64(42), true, "42"},
+		{"float64", 1.25, true, "1.25"},
+		{"bool", true, true, "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := String("previous")
+			if err := result.Scan(tt.input); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Expected valid=%v, got valid=%v", tt.expectedValid, result.Valid)
+			}
+			if result.String != tt.expectedValue {
+				t.Errorf("Expected value='%s', got value='%s'", tt.expectedValue, result.String)
+			}
+		})
+	}
+}
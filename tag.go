@@ -0,0 +1,63 @@
+package nihil
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagOptions holds the parsed options of a `nihil:"..."` struct tag
+// Keys are lower-cased; bare flags map to an empty value
+type tagOptions map[string]string
+
+// parseTag parses the nihil struct tag of a field, a comma separated list
+// of bare flags ("required") and key=value pairs ("tz=UTC"). Values may be
+// wrapped in single quotes to contain commas, with a quote doubled to write
+// one inside them:
+//
+//	pattern='^[a-z]{2,8}$'
+//	oneof='it''s,ok'
+func parseTag(tag reflect.StructTag) tagOptions {
+	opts := tagOptions{}
+	for _, part := range splitTag(tag.Get("nihil")) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		opts[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return opts
+}
+
+// splitTag splits s on commas outside single quotes, dropping the quotes
+// and unescaping doubled quotes inside them
+func splitTag(s string) []string {
+	var (
+		parts  []string
+		part   strings.Builder
+		quoted bool
+	)
+	for i, r := range s {
+		switch {
+		case r == '\'' && quoted && strings.HasPrefix(s[i+1:], "'"):
+			part.WriteRune(r)
+			quoted = false // the second quote turns it back on
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	return append(parts, part.String())
+}
+
+// TagOption returns the value of a `nihil:"..."` option of a struct field
+// and whether it is present; bare flags have an empty value. Option names
+// are case-insensitive: TagOption(tag, "uniqueIfNotNull").
+func TagOption(tag reflect.StructTag, name string) (string, bool) {
+	value, ok := parseTag(tag)[strings.ToLower(name)]
+	return value, ok
+}
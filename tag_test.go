@@ -0,0 +1,31 @@
+package nihil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagOption(t *testing.T) {
+	tag := reflect.StructTag(`nihil:"uniqueIfNotNull, tz=UTC, pattern='^[a-z]{2,8}$', oneof='it''s, ok' 'no',empty=''"`)
+
+	tests := []struct {
+		name     string
+		expected string
+		present  bool
+	}{
+		{"uniqueIfNotNull", "", true},
+		{"UNIQUEIFNOTNULL", "", true},
+		{"tz", "UTC", true},
+		{"pattern", "^[a-z]{2,8}$", true},
+		{"oneof", "it's, ok no", true},
+		{"empty", "", true},
+		{"storage", "", false},
+	}
+
+	for _, tt := range tests {
+		value, ok := TagOption(tag, tt.name)
+		if value != tt.expected || ok != tt.present {
+			t.Errorf("%s: expected %q, %v, got %q, %v", tt.name, tt.expected, tt.present, value, ok)
+		}
+	}
+}
@@ -3,6 +3,11 @@ package nihil
 import (
 	"database/sql"
 	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,12 +29,18 @@ func (n *NilTime) isValid() bool            { return n.Valid }
 func (n *NilTime) getValue() time.Time      { return n.Time }
 func (n *NilTime) setValid(valid bool)      { n.Valid = valid }
 func (n *NilTime) setValue(value time.Time) { n.Time = value }
-func (n *NilTime) scan(value any) error     { return (*sql.NullTime)(n).Scan(value) }
+func (n *NilTime) scan(value any) error {
+	if err := scanNullable(n, value, convertTime); err != nil {
+		return err
+	}
+	*n = n.Normalize(CurrentTimePolicy())
+	return nil
+}
 func (n *NilTime) driverValue() (driver.Value, error) {
 	if !n.Valid {
 		return nil, nil
 	}
-	return n.Time, nil
+	return CurrentTimePolicy().Apply(n.Time), nil
 }
 
 func (n *NilTime) Scan(value any) error        { return n.scan(value) }
@@ -37,3 +48,103 @@ func (n NilTime) Value() (driver.Value, error) { return n.driverValue() }
 
 func (n NilTime) MarshalJSON() ([]byte, error)  { return marshalNullableJSON((*NilTime)(&n)) }
 func (n *NilTime) UnmarshalJSON(b []byte) error { return unmarshalNullableJSON(n, b) }
+
+// Normalize returns n with the policy applied, leaving null values untouched
+func (n NilTime) Normalize(p TimePolicy) NilTime {
+	if n.Valid {
+		n.Time = p.Apply(n.Time)
+	}
+	return n
+}
+
+// Equal reports whether n and u are both null or hold the same instant.
+// Unlike ==, it ignores the zone and monotonic clock reading of the times.
+func (n NilTime) Equal(u NilTime) bool {
+	if !n.Valid || !u.Valid {
+		return n.Valid == u.Valid
+	}
+	return n.Time.Equal(u.Time)
+}
+
+// TimePolicy normalizes NilTime values on their way to and from the
+// database, so that a value read back equals the value that was written
+// regardless of how the driver truncates sub-second digits or zones.
+//
+// The zero value leaves values untouched.
+type TimePolicy struct {
+	Location  *time.Location // zone values are converted to; nil keeps the zone
+	Precision time.Duration  // unit values are truncated to; 0 keeps nanoseconds
+}
+
+// Apply converts t to the policy's location and truncates it to the
+// policy's precision
+func (p TimePolicy) Apply(t time.Time) time.Time {
+	if p.Precision > 0 {
+		t = t.Truncate(p.Precision)
+	}
+	if p.Location != nil {
+		t = t.In(p.Location)
+	}
+	return t
+}
+
+var timePolicy atomic.Pointer[TimePolicy]
+
+// SetTimePolicy sets the policy NilTime.Value and NilTime.Scan apply to
+// every value. It is meant to be called once during start-up:
+//
+//	nihil.SetTimePolicy(nihil.TimePolicy{Location: time.UTC, Precision: time.Microsecond})
+func SetTimePolicy(p TimePolicy) {
+	timePolicy.Store(&p)
+}
+
+// CurrentTimePolicy returns the policy set by SetTimePolicy
+func CurrentTimePolicy() TimePolicy {
+	if p := timePolicy.Load(); p != nil {
+		return *p
+	}
+	return TimePolicy{}
+}
+
+// FieldTimePolicy returns the policy for a NilTime struct field: the
+// current policy, overridden by the field's precision (fractional second
+// digits, as in GORM's precision tag) and its `nihil:"tz=..."` option
+func FieldTimePolicy(tag reflect.StructTag, precision string) (TimePolicy, error) {
+	p := CurrentTimePolicy()
+
+	if precision != "" {
+		digits, err := strconv.Atoi(precision)
+		if err != nil || digits < 0 || digits > 9 {
+			return p, fmt.Errorf("nihil: invalid time precision %q: want 0 to 9 digits", precision)
+		}
+		p.Precision = time.Second
+		for range digits {
+			p.Precision /= 10
+		}
+	}
+
+	if name, ok := parseTag(tag)["tz"]; ok {
+		loc, err := loadLocation(name)
+		if err != nil {
+			return p, fmt.Errorf("nihil: invalid time zone %q: %w", name, err)
+		}
+		p.Location = loc
+	}
+	return p, nil
+}
+
+var locations sync.Map // map[string]*time.Location
+
+// loadLocation is time.LoadLocation, cached per zone name as it runs on
+// every save and scan of a field with a tz option
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,162 @@
+package nihil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TimeStorage selects how a NilTime is encoded in its database column,
+// independently of its JSON format
+//
+// Integrations that see struct tags select it with the `nihil:"storage=..."`
+// option; plain database/sql code uses Encode and Decode, or the Valuer and
+// Scanner adapters, directly.
+type TimeStorage int
+
+const (
+	StorageNative    TimeStorage = iota // time.Time, for DATETIME/TIMESTAMP columns
+	StorageUnix                         // integer seconds since the Unix epoch
+	StorageUnixMilli                    // integer milliseconds since the Unix epoch
+	StorageUnixMicro                    // integer microseconds since the Unix epoch
+	StorageUnixNano                     // integer nanoseconds since the Unix epoch
+	StorageText                         // fixed-width UTC RFC 3339 text with nanoseconds
+)
+
+// sortableTextLayout is the StorageText layout: always UTC with nine
+// fractional digits, so stored text sorts in time order
+const sortableTextLayout = "2006-01-02T15:04:05.000000000Z"
+
+var timeStorageNames = map[TimeStorage]string{
+	StorageNative:    "native",
+	StorageUnix:      "unix",
+	StorageUnixMilli: "unixmilli",
+	StorageUnixMicro: "unixmicro",
+	StorageUnixNano:  "unixnano",
+	StorageText:      "text",
+}
+
+func (s TimeStorage) String() string {
+	if name, ok := timeStorageNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("TimeStorage(%d)", int(s))
+}
+
+// ParseTimeStorage returns the storage mode named by a `storage=` tag value
+func ParseTimeStorage(name string) (TimeStorage, error) {
+	for s, n := range timeStorageNames {
+		if strings.EqualFold(n, name) {
+			return s, nil
+		}
+	}
+	return StorageNative, fmt.Errorf("nihil: unknown time storage %q", name)
+}
+
+// IsInteger reports whether the mode stores times in an integer column
+func (s TimeStorage) IsInteger() bool {
+	return s >= StorageUnix && s <= StorageUnixNano
+}
+
+// Encode returns the driver value storing n, after applying the current
+// TimePolicy
+func (s TimeStorage) Encode(n NilTime) driver.Value {
+	if !n.Valid {
+		return nil
+	}
+
+	t := CurrentTimePolicy().Apply(n.Time)
+	switch s {
+	case StorageUnix:
+		return t.Unix()
+	case StorageUnixMilli:
+		return t.UnixMilli()
+	case StorageUnixMicro:
+		return t.UnixMicro()
+	case StorageUnixNano:
+		return t.UnixNano()
+	case StorageText:
+		return t.UTC().Format(sortableTextLayout)
+	}
+	return t
+}
+
+// Decode converts a driver value stored with the mode into a NilTime
+// Integer modes also accept timestamp text and time.Time values, so a
+// column can be migrated between encodings in place.
+func (s TimeStorage) Decode(value any) (NilTime, error) {
+	var n NilTime
+	if !s.IsInteger() {
+		err := n.Scan(value)
+		return n, err
+	}
+
+	err := scanNullable(&n, value, func(value any) (time.Time, error) {
+		if _, ok := value.(time.Time); ok {
+			return convertTime(value)
+		}
+
+		i, err := convertInteger("NilTime", value, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			if _, ok := textOf(value); ok {
+				return convertTime(value)
+			}
+			return time.Time{}, err
+		}
+		switch s {
+		case StorageUnix:
+			return time.Unix(i, 0).UTC(), nil
+		case StorageUnixMilli:
+			return time.UnixMilli(i).UTC(), nil
+		case StorageUnixMicro:
+			return time.UnixMicro(i).UTC(), nil
+		}
+		return time.Unix(0, i).UTC(), nil
+	})
+	return n.Normalize(CurrentTimePolicy()), err
+}
+
+// Valuer returns a query argument storing n with the mode
+//
+//	db.Exec("INSERT INTO events (at) VALUES (?)", nihil.StorageUnixMilli.Valuer(e.At))
+func (s TimeStorage) Valuer(n NilTime) driver.Valuer {
+	return storedTime{storage: s, time: &n}
+}
+
+// Scanner returns a Scan destination decoding into n with the mode
+//
+//	rows.Scan(nihil.StorageUnixMilli.Scanner(&e.At))
+func (s TimeStorage) Scanner(n *NilTime) sql.Scanner {
+	return storedTime{storage: s, time: n}
+}
+
+// storedTime adapts a NilTime to a storage mode for database/sql
+type storedTime struct {
+	storage TimeStorage
+	time    *NilTime
+}
+
+func (st storedTime) Value() (driver.Value, error) { return st.storage.Encode(*st.time), nil }
+
+func (st storedTime) Scan(value any) error {
+	n, err := st.storage.Decode(value)
+	if err != nil {
+		return err
+	}
+	*st.time = n
+	return nil
+}
+
+// FieldTimeStorage returns the storage mode selected by a field's
+// `nihil:"storage=..."` option
+func FieldTimeStorage(tag reflect.StructTag) (TimeStorage, error) {
+	name, ok := parseTag(tag)["storage"]
+	if !ok {
+		return StorageNative, nil
+	}
+	return ParseTimeStorage(name)
+}
@@ -2,6 +2,7 @@ package nihil
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -79,3 +80,227 @@ func TestNilTime_JSONUnmarshaling(t *testing.T) {
 		})
 	}
 }
+
+func TestNilTime_Scan(t *testing.T) {
+	testTime := time.Date(2023, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		input         any
+		expectedValid bool
+		expectedTime  time.Time
+	}{
+		{"nil", nil, false, time.Time{}},
+		{"time", testTime, true, testTime},
+		{"rfc3339", "2023-10-15T14:30:00Z", true, testTime},
+		{"rfc3339 offset", "2023-10-15T21:30:00+07:00", true, testTime},
+		{"sqlite text", "2023-10-15 14:30:00+00:00", true, testTime},
+		{"mysql bytes", []byte("2023-10-15 14:30:00"), true, testTime},
+		{"fractional", "2023-10-15 14:30:00.000", true, testTime},
+		{"minutes", "2023-10-15T14:30", true, testTime},
+		{"date only", "2023-10-15", true, time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result NilTime
+			if err := result.Scan(tt.input); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Expected valid=%v, got valid=%v", tt.expectedValid, result.Valid)
+			}
+			if !result.Time.Equal(tt.expectedTime) {
+				t.Errorf("Expected time=%v, got time=%v", tt.expectedTime, result.Time)
+			}
+		})
+	}
+}
+
+func TestNilTime_ScanInvalid(t *testing.T) {
+	for _, input := range []any{"yesterday", int64(3), true} {
+		var result NilTime
+		if err := result.Scan(input); err == nil {
+			t.Errorf("Expected error scanning %v", input)
+		}
+	}
+}
+
+func TestNilTime_Equal(t *testing.T) {
+	instant := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		a, b     NilTime
+		expected bool
+	}{
+		{"same instant in different zones", Time(instant), Time(instant.In(time.FixedZone("WIB", 7*3600))), true},
+		{"different instants", Time(instant), Time(instant.Add(time.Second)), false},
+		{"both null", TimeNil(), NilTime{Time: instant}, true},
+		{"null and valid", TimeNil(), Time(time.Time{}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.a.Equal(tt.b); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNilTime_Policy(t *testing.T) {
+	t.Cleanup(func() { SetTimePolicy(TimePolicy{}) })
+
+	local := time.FixedZone("UTC+7", 7*60*60)
+	written := time.Date(2023, 10, 15, 21, 30, 0, 123456789, local)
+	expected := time.Date(2023, 10, 15, 14, 30, 0, 123456000, time.UTC)
+
+	// The zero policy leaves values untouched
+	val, err := Time(written).Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val.(time.Time) != written {
+		t.Errorf("Expected %v, got %v", written, val)
+	}
+
+	SetTimePolicy(TimePolicy{Location: time.UTC, Precision: time.Microsecond})
+
+	val, err = Time(written).Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if val.(time.Time) != expected {
+		t.Errorf("Expected %v, got %v", expected, val)
+	}
+
+	var scanned NilTime
+	if err := scanned.Scan(written); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scanned.Time != expected {
+		t.Errorf("Expected scanned %v, got %v", expected, scanned.Time)
+	}
+
+	if nilTime := TimeNil().Normalize(CurrentTimePolicy()); nilTime.Valid {
+		t.Error("Expected null to stay null")
+	}
+}
+
+func TestTimeStorage_EncodeDecode(t *testing.T) {
+	testTime := time.Date(2023, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		storage TimeStorage
+		stored  any
+	}{
+		{StorageNative, testTime},
+		{StorageUnix, int64(1697380200)},
+		{StorageUnixMilli, int64(1697380200000)},
+		{StorageUnixMicro, int64(1697380200000000)},
+		{StorageUnixNano, int64(1697380200000000000)},
+		{StorageText, "2023-10-15T14:30:00.000000000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.storage.String(), func(t *testing.T) {
+			if encoded := tt.storage.Encode(Time(testTime)); encoded != tt.stored {
+				t.Errorf("Expected encoded %v, got %v", tt.stored, encoded)
+			}
+			if encoded := tt.storage.Encode(TimeNil()); encoded != nil {
+				t.Errorf("Expected nil for null, got %v", encoded)
+			}
+
+			decoded, err := tt.storage.Decode(tt.stored)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !decoded.Valid || !decoded.Time.Equal(testTime) {
+				t.Errorf("Expected decoded %v, got %v", testTime, decoded)
+			}
+
+			decoded, err = tt.storage.Decode(nil)
+			if err != nil || decoded.Valid {
+				t.Errorf("Expected null from nil, got %v, %v", decoded, err)
+			}
+		})
+	}
+
+	// Integer modes accept integer text and timestamp text
+	if decoded, err := StorageUnixMilli.Decode([]byte("1697380200000")); err != nil || !decoded.Time.Equal(testTime) {
+		t.Errorf("Expected decoded from text, got %v, %v", decoded, err)
+	}
+	if decoded, err := StorageUnix.Decode("2023-10-15 14:30:00"); err != nil || !decoded.Time.Equal(testTime) {
+		t.Errorf("Expected decoded from timestamp text, got %v, %v", decoded, err)
+	}
+	if _, err := StorageUnix.Decode(1.5); err == nil {
+		t.Error("Expected error decoding a fractional number")
+	}
+
+	// Text is UTC and fixed width, so it sorts like the times it stores
+	early := StorageText.Encode(Time(time.Date(2023, 10, 15, 16, 30, 0, 0, time.FixedZone("CEST", 2*3600))))
+	late := StorageText.Encode(Time(testTime.Add(500 * time.Millisecond)))
+	if early != "2023-10-15T14:30:00.000000000Z" || late != "2023-10-15T14:30:00.500000000Z" || early.(string) >= late.(string) {
+		t.Errorf("Expected sortable UTC text, got %v and %v", early, late)
+	}
+
+	if _, err := ParseTimeStorage("unixmilli"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := ParseTimeStorage("weekly"); err == nil {
+		t.Error("Expected error for unknown storage")
+	}
+}
+
+func TestFieldTimePolicy(t *testing.T) {
+	tests := []struct {
+		tag       reflect.StructTag
+		precision string
+		expected  TimePolicy
+		wantErr   bool
+	}{
+		{``, "", TimePolicy{}, false},
+		{``, "3", TimePolicy{Precision: time.Millisecond}, false},
+		{`nihil:"tz=UTC"`, "0", TimePolicy{Location: time.UTC, Precision: time.Second}, false},
+		{``, "10", TimePolicy{}, true},
+		{`nihil:"tz=Nowhere/Special"`, "", TimePolicy{}, true},
+	}
+
+	for _, tt := range tests {
+		policy, err := FieldTimePolicy(tt.tag, tt.precision)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s %q: expected error %v, got %v", tt.tag, tt.precision, tt.wantErr, err)
+			continue
+		}
+		if err == nil && policy != tt.expected {
+			t.Errorf("%s %q: expected %+v, got %+v", tt.tag, tt.precision, tt.expected, policy)
+		}
+	}
+}
+
+func TestFieldTimePolicy_CachesLocation(t *testing.T) {
+	tag := reflect.StructTag(`nihil:"tz=Asia/Tokyo"`)
+	first, err := FieldTimePolicy(tag, "")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	second, _ := FieldTimePolicy(tag, "")
+	if first.Location != second.Location {
+		t.Errorf("Expected the cached location to be reused, got %p and %p", first.Location, second.Location)
+	}
+	if first.Location.String() != "Asia/Tokyo" {
+		t.Errorf("Expected Asia/Tokyo, got %s", first.Location)
+	}
+}
+
+func TestFieldTimeStorage(t *testing.T) {
+	if storage, err := FieldTimeStorage(``); err != nil || storage != StorageNative {
+		t.Errorf("Expected native storage, got %v (%v)", storage, err)
+	}
+	if storage, err := FieldTimeStorage(`nihil:"storage=unixmilli"`); err != nil || storage != StorageUnixMilli {
+		t.Errorf("Expected unixmilli storage, got %v (%v)", storage, err)
+	}
+	if _, err := FieldTimeStorage(`nihil:"storage=weekly"`); err == nil {
+		t.Error("Expected error for unknown storage mode")
+	}
+}
@@ -0,0 +1,142 @@
+package nihil
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// updateColumn is one column assignment of a partial update
+type updateColumn struct {
+	name  string
+	value any
+}
+
+// UpdateMap returns the columns a patch struct sets, for GORM's Updates:
+//
+//	set, err := nihil.UpdateMap(patch)
+//	db.Model(&user).Updates(set)
+//
+// Unlike Updates with a struct, null and zero values are written. A field
+// is left out only when it is an unset Optional, a nil pointer, or tagged
+// `db:"-"` or `gorm:"-"`, so explicit nulls become NULL while untouched
+// columns keep their value. Values keep their type, so nihil values reach
+// the driver through their Value method. Columns are named by the GORM
+// column setting, the db tag, or the snake_case field name, and embedded
+// structs contribute their fields.
+func UpdateMap(patch any) (map[string]any, error) {
+	columns, err := updateColumns(patch)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]any, len(columns))
+	for _, c := range columns {
+		set[c.name] = c.value
+	}
+	return set, nil
+}
+
+// UpdateSQL returns the SET list of an UPDATE statement for the columns a
+// patch struct sets, as selected by UpdateMap, with bind parameters in the
+// dialect's style and the matching arguments:
+//
+//	set, args, err := nihil.UpdateSQL("postgres", patch)
+//	// set: "email" = $1, "middle_name" = $2
+//	query := "UPDATE users SET " + set + fmt.Sprintf(" WHERE id = $%d", len(args)+1)
+//	db.ExecContext(ctx, query, append(args, id)...)
+//
+// Columns are listed in field order. set is empty when the patch sets no
+// column.
+func UpdateSQL(dialect string, patch any) (string, []any, error) {
+	columns, err := updateColumns(patch)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quote, placeholder := sqlSyntax(dialect)
+	assignments := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, c := range columns {
+		assignments[i] = quote(c.name) + " = " + placeholder(i+1)
+		args[i] = c.value
+	}
+	return strings.Join(assignments, ", "), args, nil
+}
+
+// updateColumns returns the column assignments of a patch struct
+func updateColumns(patch any) ([]updateColumn, error) {
+	rv := reflect.ValueOf(patch)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("nihil: update patch must be a struct, got nil %T", patch)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isNullable(rv.Type()) {
+		return nil, fmt.Errorf("nihil: update patch must be a struct, got %T", patch)
+	}
+
+	var columns []updateColumn
+	collectUpdateColumns(rv, map[string]bool{}, &columns)
+	return columns, nil
+}
+
+func collectUpdateColumns(rv reflect.Value, seen map[string]bool, columns *[]updateColumn) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := rv.Field(i)
+
+		if sf.Anonymous && isEmbeddedColumns(sf.Type) {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			collectUpdateColumns(fv, seen, columns)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, ok := columnName(sf)
+		if !ok || seen[name] {
+			continue
+		}
+
+		value, ok := updateValue(fv)
+		if !ok {
+			continue
+		}
+		seen[name] = true
+		*columns = append(*columns, updateColumn{name: name, value: value})
+	}
+}
+
+// updateValue returns the value a patch field writes, and false for unset
+// Optional fields and nil pointers
+func updateValue(fv reflect.Value) (any, bool) {
+	if o, ok := fv.Interface().(optional); ok {
+		return o.optionalValue()
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil, false
+		}
+		return fv.Elem().Interface(), true
+	}
+	return fv.Interface(), true
+}
+
+// isEmbeddedColumns reports whether the fields of an embedded type are
+// columns of the outer struct, rather than the embedded type being one
+func isEmbeddedColumns(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isNullable(t) &&
+		!t.Implements(reflect.TypeFor[driver.Valuer]()) &&
+		!reflect.PointerTo(t).Implements(reflect.TypeFor[optional]())
+}
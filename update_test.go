@@ -0,0 +1,121 @@
+package nihil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type updateAudit struct {
+	UpdatedBy NilString
+}
+
+type updatePatch struct {
+	updateAudit
+	Email      Optional[NilString]
+	MiddleName Optional[NilString] `db:"middle"`
+	Age        *NilInt32
+	Nickname   *string `gorm:"column:nick_name"`
+	UserID     NilInt64
+	Status     string
+	Ignored    string `db:"-"`
+	Skipped    string `gorm:"-"`
+	Dropped    string `gorm:"-:all"`
+	Computed   string `gorm:"-:migration"`
+	internal   string
+}
+
+func TestUpdateMap(t *testing.T) {
+	age := Int32(30)
+	patch := updatePatch{
+		updateAudit: updateAudit{UpdatedBy: String("admin")},
+		MiddleName:  Set(StringNil()),
+		Age:         &age,
+		UserID:      Int64Nil(),
+		Ignored:     "x",
+		Skipped:     "x",
+		Dropped:     "x",
+		Computed:    "x",
+		internal:    "x",
+	}
+
+	set, err := UpdateMap(&patch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"updated_by": String("admin"),
+		"middle":     StringNil(),
+		"age":        Int32(30),
+		"user_id":    Int64Nil(),
+		"status":     "",
+		"computed":   "x",
+	}
+	if !reflect.DeepEqual(set, expected) {
+		t.Errorf("Expected %v, got %v", expected, set)
+	}
+}
+
+func TestUpdateSQL(t *testing.T) {
+	nickname := "al"
+	patch := updatePatch{Email: Set(String("a@b.c")), MiddleName: Set(StringNil()), Nickname: &nickname}
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{"postgres", `"updated_by" = $1, "email" = $2, "middle" = $3, "nick_name" = $4, "user_id" = $5, "status" = $6, "computed" = $7`},
+		{"mysql", "`updated_by` = ?, `email` = ?, `middle` = ?, `nick_name` = ?, `user_id` = ?, `status` = ?, `computed` = ?"},
+		{"sqlserver", `"updated_by" = @p1, "email" = @p2, "middle" = @p3, "nick_name" = @p4, "user_id" = @p5, "status" = @p6, "computed" = @p7`},
+		{"unknown", `"updated_by" = ?, "email" = ?, "middle" = ?, "nick_name" = ?, "user_id" = ?, "status" = ?, "computed" = ?`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			set, args, err := UpdateSQL(tt.dialect, patch)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if set != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, set)
+			}
+			expectedArgs := []any{StringNil(), String("a@b.c"), StringNil(), "al", NilInt64{}, "", ""}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Errorf("Expected args %v, got %v", expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestUpdateSQL_NothingSet(t *testing.T) {
+	type onlyOptional struct {
+		Email Optional[NilString]
+	}
+	set, args, err := UpdateSQL("postgres", onlyOptional{})
+	if err != nil || set != "" || len(args) != 0 {
+		t.Errorf("Expected an empty SET list, got %q %v %v", set, args, err)
+	}
+}
+
+func TestUpdateMap_Errors(t *testing.T) {
+	for _, patch := range []any{nil, (*updatePatch)(nil), "email", String("a")} {
+		if _, err := UpdateMap(patch); err == nil || !strings.Contains(err.Error(), "update patch must be a struct") {
+			t.Errorf("Expected an error for %#v, got %v", patch, err)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":         "name",
+		"UserID":       "user_id",
+		"HTTPStatus":   "http_status",
+		"CreatedAt":    "created_at",
+		"Address1Line": "address1_line",
+		"ID":           "id",
+	}
+	for input, expected := range tests {
+		if result := snakeCase(input); result != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, input, result)
+		}
+	}
+}
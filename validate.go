@@ -0,0 +1,301 @@
+package nihil
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError describes one constraint violated by a nihil field
+type ValidationError struct {
+	Path    string // JSON path of the field, e.g. "address.zip"
+	Rule    string // violated constraint, e.g. "max"
+	Param   string // constraint parameter, e.g. "10"
+	Message string // human readable description, e.g. "must be at most 10"
+}
+
+func (e *ValidationError) Error() string {
+	return "nihil: field " + strconv.Quote(e.Path) + " " + e.Message
+}
+
+// ValidationErrors lists every violation found by Validate
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// valueRules are the constraints checked on non-null values, in order
+var valueRules = []string{"len", "min", "max", "pattern", "oneof", "past", "future"}
+
+// Validate checks the constraints declared in `nihil:"..."` tags on the
+// nihil fields of v, a struct or a pointer to one. Nested structs, slices
+// and maps are walked, and violations are reported with their JSON path.
+//
+// Only notnull and required look at null values; every other constraint
+// applies to non-null values only:
+//
+//	notnull       the value must not be null
+//	required      the value must not be null or the zero value ("", 0, false, zero time)
+//	min=N, max=N  bounds for numbers, or for the length in characters of strings
+//	len=N         exact length in characters of strings
+//	pattern=RE    strings must match the regular expression
+//	oneof=A B C   the value must be one of the space separated options
+//	past, future  times must be before or after the current time
+//
+// Validate returns nil, a ValidationErrors listing every violation, or an
+// error describing a malformed constraint.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fmt.Errorf("nihil: Validate expects a struct, got nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("nihil: Validate expects a struct, got %T", v)
+	}
+
+	var errs ValidationErrors
+	if err := validateValue(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateValue walks rv collecting violations of nihil fields into errs
+func validateValue(rv reflect.Value, path string, errs *ValidationErrors) error {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if isNullable(rv.Type()) {
+			return nil
+		}
+		for _, f := range jsonFields(rv.Type()) {
+			fv, err := rv.FieldByIndexErr(f.Index)
+			if err != nil {
+				continue // field of a nil embedded pointer
+			}
+			fieldPath := joinPath(path, f.Name)
+
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if !isNullable(ft) {
+				if err := validateValue(fv, fieldPath, errs); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv = reflect.Zero(ft)
+				} else {
+					fv = fv.Elem()
+				}
+			}
+			if err := validateField(fv, parseTag(f.Tag), fieldPath, errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := validateValue(rv.Index(i), path+"["+strconv.Itoa(i)+"]", errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			if err := validateValue(rv.MapIndex(key), joinPath(path, fmt.Sprint(key.Interface())), errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateField checks one nihil field against its tag options
+func validateField(rv reflect.Value, opts tagOptions, path string, errs *ValidationErrors) error {
+	value, valid := nullableValue(rv)
+	if sv := reflect.ValueOf(value); sv.Kind() == reflect.String {
+		value = sv.String() // string-like enum values
+	}
+
+	violation := func(rule, message string) {
+		*errs = append(*errs, &ValidationError{Path: path, Rule: rule, Param: opts[rule], Message: message})
+	}
+
+	if !valid {
+		if _, ok := opts["notnull"]; ok {
+			violation("notnull", "must not be null")
+		} else if _, ok := opts["required"]; ok {
+			violation("required", "is required")
+		}
+		return nil
+	}
+
+	if _, ok := opts["required"]; ok && reflect.ValueOf(value).IsZero() {
+		violation("required", "is required")
+	}
+
+	for _, rule := range valueRules {
+		param, ok := opts[rule]
+		if !ok {
+			continue
+		}
+		message, err := checkRule(rule, param, value)
+		if err != nil {
+			return fmt.Errorf("nihil: field %q: %s=%s: %w", path, rule, param, err)
+		}
+		if message != "" {
+			violation(rule, message)
+		}
+	}
+	return nil
+}
+
+// checkRule returns the violation message of a constraint, or "" when the
+// value satisfies it
+func checkRule(rule, param string, value any) (string, error) {
+	switch rule {
+	case "len", "min", "max":
+		if s, ok := value.(string); ok {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return "", errors.New("want an integer length")
+			}
+			length := utf8.RuneCountInString(s)
+			switch {
+			case rule == "len" && length != n:
+				return fmt.Sprintf("must be exactly %d characters", n), nil
+			case rule == "min" && length < n:
+				return fmt.Sprintf("must be at least %d characters", n), nil
+			case rule == "max" && length > n:
+				return fmt.Sprintf("must be at most %d characters", n), nil
+			}
+			return "", nil
+		}
+		if rule == "len" {
+			break
+		}
+		order, err := compareNumber(value, param)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case rule == "min" && order < 0:
+			return "must be at least " + param, nil
+		case rule == "max" && order > 0:
+			return "must be at most " + param, nil
+		}
+		return "", nil
+	case "pattern":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		re, err := compilePattern(param)
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(s) {
+			return "must match " + param, nil
+		}
+		return "", nil
+	case "oneof":
+		if _, ok := value.(time.Time); ok {
+			break
+		}
+		options := strings.Fields(param)
+		if !slices.Contains(options, fmt.Sprint(value)) {
+			return "must be one of " + strings.Join(options, ", "), nil
+		}
+		return "", nil
+	case "past", "future":
+		t, ok := value.(time.Time)
+		if !ok {
+			break
+		}
+		now := time.Now()
+		if rule == "past" && !t.Before(now) {
+			return "must be in the past", nil
+		}
+		if rule == "future" && !t.After(now) {
+			return "must be in the future", nil
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("does not apply to %T values", value)
+}
+
+// compareNumber compares a numeric value with a numeric parameter,
+// returning -1, 0 or +1
+func compareNumber(value any, param string) (int, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bound, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return 0, errors.New("want an integer bound")
+		}
+		return cmp.Compare(rv.Int(), bound), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bound, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return 0, errors.New("want an integer bound")
+		}
+		if bound < 0 {
+			return 1, nil
+		}
+		return cmp.Compare(rv.Uint(), uint64(bound)), nil
+	case reflect.Float32, reflect.Float64:
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return 0, errors.New("want a numeric bound")
+		}
+		return cmp.Compare(rv.Float(), bound), nil
+	}
+	return 0, fmt.Errorf("does not apply to %T values", value)
+}
+
+var patternCache sync.Map // map[string]*regexp.Regexp
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,150 @@
+package nihil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type validateAddress struct {
+	Zip NilString `json:"zip" nihil:"len=5,pattern=^[0-9]+$"`
+}
+
+type validateRequest struct {
+	Name     NilString         `json:"name"      nihil:"required,max=10"`
+	Nickname NilString         `json:"nickname"  nihil:"min=2"`
+	Age      NilInt32          `json:"age"       nihil:"min=0,max=150"`
+	Email    NilString         `json:"email"     nihil:"notnull"`
+	Code     NilString         `json:"code"      nihil:"pattern='^[A-Z]{2,3}$'"`
+	Status   NilString         `json:"status"    nihil:"oneof=active inactive"`
+	Level    NilByte           `json:"level"     nihil:"oneof=1 2 3"`
+	Ratio    NilFloat64        `json:"ratio"     nihil:"max=1.5"`
+	StartsAt NilTime           `json:"starts_at" nihil:"future"`
+	BornAt   *NilTime          `json:"born_at"   nihil:"past"`
+	Address  validateAddress   `json:"address"`
+	Previous []validateAddress `json:"previous"`
+	Plain    string            `json:"plain"`
+}
+
+func validRequest() validateRequest {
+	born := Time(time.Now().Add(-time.Hour))
+	return validateRequest{
+		Name:     String("Alice"),
+		Age:      Int32(30),
+		Email:    String("alice@example.com"),
+		Code:     String("ABC"),
+		Status:   String("active"),
+		Level:    Byte(2),
+		Ratio:    Float64(1.5),
+		StartsAt: Time(time.Now().Add(time.Hour)),
+		BornAt:   &born,
+		Address:  validateAddress{Zip: String("12345")},
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	req := validRequest()
+	if err := Validate(&req); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Constraints other than required and notnull skip null values
+	req.Age = Int32Nil()
+	req.Code = StringNil()
+	req.StartsAt = TimeNil()
+	req.BornAt = nil
+	req.Address.Zip = StringNil()
+	if err := Validate(req); err != nil {
+		t.Errorf("Unexpected error for null values: %v", err)
+	}
+}
+
+func TestValidate_Violations(t *testing.T) {
+	past := Time(time.Now().Add(-time.Hour))
+	future := Time(time.Now().Add(time.Hour))
+
+	req := validateRequest{
+		Name:     String("Alexander the Great"),
+		Nickname: String("A"),
+		Age:      Int32(200),
+		Email:    StringNil(),
+		Code:     String("abc"),
+		Status:   String("actve"),
+		Level:    Byte(9),
+		Ratio:    Float64(2),
+		StartsAt: past,
+		BornAt:   &future,
+		Address:  validateAddress{Zip: String("12a4")},
+		Previous: []validateAddress{{Zip: String("12345")}, {Zip: String("1234X")}},
+	}
+
+	err := Validate(&req)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	expected := []struct{ path, rule string }{
+		{"name", "max"},
+		{"nickname", "min"},
+		{"age", "max"},
+		{"email", "notnull"},
+		{"code", "pattern"},
+		{"status", "oneof"},
+		{"level", "oneof"},
+		{"ratio", "max"},
+		{"starts_at", "future"},
+		{"born_at", "past"},
+		{"address.zip", "len"},
+		{"address.zip", "pattern"},
+		{"previous[1].zip", "pattern"},
+	}
+	if len(verrs) != len(expected) {
+		t.Fatalf("Expected %d violations, got %d: %v", len(expected), len(verrs), verrs)
+	}
+	for i, e := range expected {
+		if verrs[i].Path != e.path || verrs[i].Rule != e.rule {
+			t.Errorf("Violation %d: expected %s/%s, got %s/%s", i, e.path, e.rule, verrs[i].Path, verrs[i].Rule)
+		}
+	}
+
+	if !strings.Contains(err.Error(), `field "name" must be at most 10 characters`) {
+		t.Errorf("Unexpected message: %v", err)
+	}
+}
+
+func TestValidate_Required(t *testing.T) {
+	type form struct {
+		Name NilString `json:"name" nihil:"required"`
+	}
+
+	for _, value := range []NilString{StringNil(), String("")} {
+		err := Validate(form{Name: value})
+		var verrs ValidationErrors
+		if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Rule != "required" {
+			t.Errorf("Expected required violation for %+v, got %v", value, err)
+		}
+	}
+}
+
+func TestValidate_InvalidConstraint(t *testing.T) {
+	type badBound struct {
+		Age NilInt32 `json:"age" nihil:"min=young"`
+	}
+	type badRule struct {
+		Active NilBool `json:"active" nihil:"max=1"`
+	}
+
+	for _, v := range []any{badBound{Age: Int32(1)}, badRule{Active: Bool(true)}} {
+		err := Validate(v)
+		var verrs ValidationErrors
+		if err == nil || errors.As(err, &verrs) {
+			t.Errorf("Expected configuration error for %T, got %v", v, err)
+		}
+	}
+
+	if err := Validate("not a struct"); err == nil {
+		t.Error("Expected error for non-struct input")
+	}
+}